@@ -0,0 +1,101 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// APIVersionHeader is the header clients request a version with, also echoed
+// on responses
+const APIVersionHeader = "X-API-Version"
+
+type apiVersionKey struct{}
+
+// ContextWithAPIVersion stores the resolved API version in the context
+func ContextWithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, version)
+}
+
+// APIVersionFromContext returns the API version stored in the context, or ""
+func APIVersionFromContext(ctx context.Context) string {
+	version, _ := ctx.Value(apiVersionKey{}).(string)
+	return version
+}
+
+// requestedAPIVersion reads the version from the X-API-Version header, or from
+// a version parameter of the Accept header such as
+// "application/json; version=2"
+func requestedAPIVersion(req *http.Request) string {
+	if v := strings.TrimSpace(req.Header.Get(APIVersionHeader)); v != "" {
+		return v
+	}
+
+	for _, accept := range req.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			fields := strings.Split(part, ";")
+			for _, param := range fields[1:] {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(key, "version") {
+					return strings.Trim(value, `"`)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// APIVersionMiddleware resolves the API version of each request from the
+// X-API-Version header or the Accept version parameter, falling back to
+// Config.APIVersion. Versions outside Config.APIVersions, when set, are
+// answered with 400 Bad Request. The resolved version is stored in the request
+// context, echoed in the X-API-Version header and reported as api_version by
+// responses sent with SendWithRequest or SendWithContext
+func (rs *Responder) APIVersionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		config := rs.GetConfig()
+		w.Header().Add("Vary", APIVersionHeader)
+
+		version := requestedAPIVersion(req)
+		if version == "" {
+			version = config.APIVersion
+		} else if len(config.APIVersions) > 0 && !slices.Contains(config.APIVersions, version) {
+			_ = rs.BadRequest("Unsupported API version").
+				appendTraceInternal("version", "supported versions: "+strings.Join(config.APIVersions, ", ")).
+				SendWithRequest(req, w)
+			return
+		}
+
+		if version != "" {
+			w.Header().Set(APIVersionHeader, version)
+			req = req.WithContext(ContextWithAPIVersion(req.Context(), version))
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// APIVersionMiddleware resolves API versions with the default Responder
+func APIVersionMiddleware(next http.Handler) http.Handler {
+	return defaultResponder.APIVersionMiddleware(next)
+}
+
+// WithAPIVersion sets the API version reported in the envelope
+func (r *Response) WithAPIVersion(version string) *Response {
+	r.APIVersion = version
+	return r
+}
+
+// applyAPIVersion fills APIVersion from the context, or from Config.APIVersion,
+// unless it was already set
+func (r *Response) applyAPIVersion(ctx context.Context) {
+	if r.APIVersion != "" {
+		return
+	}
+	if ctx != nil {
+		r.APIVersion = APIVersionFromContext(ctx)
+	}
+	if r.APIVersion == "" {
+		r.APIVersion = r.getResponseConfig().APIVersion
+	}
+}
@@ -0,0 +1,128 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// asyncPool runs async interceptors on a bounded set of workers
+type asyncPool struct {
+	once    sync.Once
+	tasks   chan func()
+	pending atomic.Int64 // queued or running tasks
+}
+
+// submit queues a task without blocking, reporting false when the queue is
+// full and the task was dropped. The pool is started on first use with the
+// sizes of the configuration at that time
+func (p *asyncPool) submit(config Config, task func()) bool {
+	p.once.Do(func() {
+		p.tasks = make(chan func(), config.AsyncQueueSize)
+		for range config.AsyncWorkers {
+			go func() {
+				for task := range p.tasks {
+					task()
+					p.pending.Add(-1)
+				}
+			}()
+		}
+	})
+
+	p.pending.Add(1)
+	select {
+	case p.tasks <- task:
+		return true
+	default:
+		p.pending.Add(-1)
+		return false
+	}
+}
+
+// Async runs the interceptor on the worker pool of the Responder instead of
+// on the send path, so a slow sink never delays responses. It receives a
+// clone of the response and a context that is not canceled with the request
+// but expires after timeout. Once the timeout expires the worker stops
+// waiting for the call, so interceptors ignoring their context cannot hold it,
+// and the call is reported through Config.OnInterceptorError. No timeout is
+// applied if it is zero. Responses are dropped for the interceptor when the
+// queue (Config.AsyncQueueSize) is full
+func Async(timeout time.Duration) InterceptorOption {
+	return func(reg *registeredInterceptor) {
+		reg.async = true
+		reg.timeout = timeout
+	}
+}
+
+// WaitAsync blocks until the queued async interceptors finished or ctx is
+// done, typically during shutdown
+func (rs *Responder) WaitAsync(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for rs.async.pending.Load() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// WaitAsync waits for the async interceptors of the default Responder
+func WaitAsync(ctx context.Context) error {
+	return defaultResponder.WaitAsync(ctx)
+}
+
+// interceptAsync queues an async interceptor call on a copy of the response
+func (rs *Responder) interceptAsync(ctx context.Context, req *http.Request, reg registeredInterceptor, resp *Response) {
+	snapshot := resp.Clone()
+	queued := rs.async.submit(rs.GetConfig(), func() {
+		// Detached from the request cancelation but keeping its values
+		taskCtx := context.Background()
+		if ctx != nil {
+			taskCtx = context.WithoutCancel(ctx)
+		}
+		if reg.timeout <= 0 {
+			rs.callInterceptor(taskCtx, requestWithContext(req, taskCtx), reg, snapshot)
+			return
+		}
+
+		taskCtx, cancel := context.WithTimeout(taskCtx, reg.timeout)
+		defer cancel()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			rs.callInterceptor(taskCtx, requestWithContext(req, taskCtx), reg, snapshot)
+		}()
+
+		select {
+		case <-done:
+		case <-taskCtx.Done():
+			select {
+			case <-done:
+			default:
+				rs.reportInterceptorError(&InterceptorError{
+					Name: reg.name,
+					Msg:  fmt.Sprintf("async call still running after %s, no longer waiting for it", reg.timeout),
+				})
+			}
+		}
+	})
+	if !queued {
+		rs.reportInterceptorError(&InterceptorError{Name: reg.name, Msg: "async queue full, response dropped"})
+	}
+}
+
+// requestWithContext returns req with ctx, or nil when req is nil
+func requestWithContext(req *http.Request, ctx context.Context) *http.Request {
+	if req == nil {
+		return nil
+	}
+	return req.WithContext(ctx)
+}
@@ -0,0 +1,85 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingInterceptor never returns and ignores its context
+type blockingInterceptor struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (b *blockingInterceptor) Intercept(ctx context.Context, r *Response, code int) {
+	b.calls.Add(1)
+	<-b.release
+}
+
+func (b *blockingInterceptor) InterceptSimple(r *Response, code int) {
+	b.calls.Add(1)
+	<-b.release
+}
+
+func TestAsyncTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		send func(r *Response) error
+	}{
+		{"without context", func(r *Response) error { return r.Send(httptest.NewRecorder()) }},
+		{"with context", func(r *Response) error {
+			return r.SendWithContext(context.Background(), httptest.NewRecorder())
+		}},
+		{"with request", func(r *Response) error {
+			return r.SendWithRequest(httptest.NewRequest("GET", "/", nil), httptest.NewRecorder())
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var reported []error
+			rs := NewResponder(Config{
+				AsyncWorkers:   1,
+				AsyncQueueSize: 4,
+				OnInterceptorError: func(err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					reported = append(reported, err)
+				},
+			})
+
+			interceptor := &blockingInterceptor{release: make(chan struct{})}
+			defer close(interceptor.release)
+			if err := rs.AddInterceptor(interceptor, Async(10*time.Millisecond)); err != nil {
+				t.Fatal(err)
+			}
+
+			for range 3 {
+				if err := tt.send(rs.OK()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if err := rs.WaitAsync(ctx); err != nil {
+				t.Fatalf("worker still held by the interceptor: %v", err)
+			}
+
+			if got := interceptor.calls.Load(); got != 3 {
+				t.Fatalf("calls = %d, want 3", got)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(reported) != 3 || !errors.Is(reported[0], ErrInterceptorFailed) {
+				t.Fatalf("reported = %v, want 3 interceptor errors", reported)
+			}
+		})
+	}
+}
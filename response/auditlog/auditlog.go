@@ -0,0 +1,134 @@
+// Package auditlog appends every response envelope as a JSON line to a writer,
+// giving handlers an audit trail without logging in each of them.
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// Record is one line of the audit log
+type Record struct {
+	Time     time.Time          `json:"time"`
+	Code     int                `json:"code"`
+	Method   string             `json:"method,omitempty"`
+	Path     string             `json:"path,omitempty"`
+	Response *response.Response `json:"response"`
+}
+
+// Logger is a response interceptor writing a Record per response
+type Logger struct {
+	mu         sync.Mutex
+	w          io.Writer
+	sampleRate float64
+	redact     bool
+	onError    func(error)
+}
+
+// Option configures a Logger
+type Option func(*Logger)
+
+// WithSampleRate records only the given fraction of responses, between 0 and 1
+func WithSampleRate(rate float64) Option {
+	return func(l *Logger) {
+		l.sampleRate = min(max(rate, 0), 1)
+	}
+}
+
+// WithRedaction applies the Responder's redaction rules before recording,
+// see response.AddRedaction
+func WithRedaction() Option {
+	return func(l *Logger) {
+		l.redact = true
+	}
+}
+
+// WithErrorHandler is called when a record cannot be encoded or written.
+// Errors are logged with the standard logger by default
+func WithErrorHandler(fn func(error)) Option {
+	return func(l *Logger) {
+		l.onError = fn
+	}
+}
+
+// New creates a Logger writing to w. Use a RotatingFile to cap the file size
+func New(w io.Writer, opts ...Option) *Logger {
+	l := &Logger{
+		w:          w,
+		sampleRate: 1,
+		onError: func(err error) {
+			log.Printf("auditlog: %v", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+func (l *Logger) Intercept(_ context.Context, resp *response.Response, statusCode int) {
+	l.record(nil, resp, statusCode)
+}
+
+func (l *Logger) InterceptSimple(resp *response.Response, statusCode int) {
+	l.record(nil, resp, statusCode)
+}
+
+// InterceptRequest adds the request method and path to the record
+func (l *Logger) InterceptRequest(req *http.Request, resp *response.Response, statusCode int) {
+	l.record(req, resp, statusCode)
+}
+
+func (l *Logger) record(req *http.Request, resp *response.Response, statusCode int) {
+	if l.sampleRate < 1 && rand.Float64() >= l.sampleRate {
+		return
+	}
+	if l.redact {
+		resp = resp.Redacted()
+	}
+
+	rec := Record{
+		Time:     time.Now().UTC(),
+		Code:     statusCode,
+		Response: resp,
+	}
+	if req != nil {
+		rec.Method = req.Method
+		rec.Path = req.URL.Path
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		l.onError(err)
+		return
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(line); err != nil {
+		l.onError(err)
+	}
+}
+
+// Register adds an audit logger writing to w to the given Responder
+func Register(w io.Writer, opts []Option, rs ...*response.Responder) (*Logger, error) {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+
+	l := New(w, opts...)
+	if err := target.AddNamedInterceptor("audit", l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,60 @@
+package response
+
+import (
+	"sort"
+	"strings"
+)
+
+// WithChallenge adds a WWW-Authenticate challenge, usually on a 401 response
+// Parameters are quoted, realm comes first and the rest are sorted
+func (r *Response) WithChallenge(scheme string, params map[string]string) *Response {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if key != "realm" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if _, ok := params["realm"]; ok {
+		keys = append([]string{"realm"}, keys...)
+	}
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+quoteParam(params[key]))
+	}
+
+	challenge := scheme
+	if len(parts) > 0 {
+		challenge += " " + strings.Join(parts, ", ")
+	}
+	return r.AddHeader("WWW-Authenticate", challenge)
+}
+
+// WithBasicChallenge adds a Basic challenge for realm
+func (r *Response) WithBasicChallenge(realm string) *Response {
+	return r.WithChallenge("Basic", map[string]string{"realm": realm, "charset": "UTF-8"})
+}
+
+// WithBearerChallenge adds a Bearer challenge (RFC 6750), errorCode is one of
+// invalid_request, invalid_token or insufficient_scope. Empty values are omitted
+func (r *Response) WithBearerChallenge(realm, errorCode, description string) *Response {
+	params := make(map[string]string)
+	if realm != "" {
+		params["realm"] = realm
+	}
+	if errorCode != "" {
+		params["error"] = errorCode
+	}
+	if description != "" {
+		params["error_description"] = description
+	}
+	return r.WithChallenge("Bearer", params)
+}
+
+// quoteParam renders an auth parameter value as a quoted-string
+func quoteParam(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
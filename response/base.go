@@ -1,50 +1,23 @@
 package response
 
-import (
-	"net/http"
-	"time"
-)
+import "net/http"
 
 func newBaseResponse(code int, msg ...string) *Response {
-	var message string
-	if len(msg) > 0 {
-		message = msg[0]
-	} else {
-		message = ""
-	}
-
-	config := getConfig()
-	return &Response{
-		Code:        code,
-		Message:     message,
-		Timestamp:   time.Now(),
-		ContentType: config.DefaultContentType,
-		Module:      config.DefaultModule,
-	}
+	return defaultResponder.newResponse(code, msg...)
 }
 
 func Base(cfg ...*Config) *Response {
-	var conf *Config
-	if len(cfg) > 0 && cfg[0] != nil {
-		conf = cfg[0]
-	} else {
-		c := getConfig()
-		conf = &c
-	}
-
-	return &Response{
-		ContentType: conf.DefaultContentType,
-		config:      *conf,
-	}
+	return defaultResponder.Base(cfg...)
 }
 
+// applyMessage is called by the status methods after setting the code
 func (r *Response) applyMessage(msg ...string) *Response {
 	if len(msg) > 0 {
 		r.Message = msg[0]
 	} else {
 		r.Message = ""
 	}
-	return r
+	return r.captureStackFor(r.getResponseConfig())
 }
 
 // Standard HTTP response builders
@@ -54,12 +27,18 @@ func OK(msg ...string) *Response {
 func Created(msg ...string) *Response {
 	return newBaseResponse(http.StatusCreated, msg...)
 }
+func CreatedAt(location string, msg ...string) *Response {
+	return defaultResponder.CreatedAt(location, msg...)
+}
 func Accepted(msg ...string) *Response {
 	return newBaseResponse(http.StatusAccepted, msg...)
 }
 func NoContent(msg ...string) *Response {
 	return newBaseResponse(http.StatusNoContent, msg...)
 }
+func NotModified(msg ...string) *Response {
+	return newBaseResponse(http.StatusNotModified, msg...)
+}
 func BadRequest(msg ...string) *Response {
 	return newBaseResponse(http.StatusBadRequest, msg...)
 }
@@ -78,15 +57,75 @@ func NotFound(msg ...string) *Response {
 func MethodNotAllowed(msg ...string) *Response {
 	return newBaseResponse(http.StatusMethodNotAllowed, msg...)
 }
+func NotAcceptable(msg ...string) *Response {
+	return newBaseResponse(http.StatusNotAcceptable, msg...)
+}
+func ProxyAuthRequired(msg ...string) *Response {
+	return newBaseResponse(http.StatusProxyAuthRequired, msg...)
+}
+func RequestTimeout(msg ...string) *Response {
+	return newBaseResponse(http.StatusRequestTimeout, msg...)
+}
 func Conflict(msg ...string) *Response {
 	return newBaseResponse(http.StatusConflict, msg...)
 }
+func Gone(msg ...string) *Response {
+	return newBaseResponse(http.StatusGone, msg...)
+}
+func LengthRequired(msg ...string) *Response {
+	return newBaseResponse(http.StatusLengthRequired, msg...)
+}
+func PreconditionFailed(msg ...string) *Response {
+	return newBaseResponse(http.StatusPreconditionFailed, msg...)
+}
+func RequestEntityTooLarge(msg ...string) *Response {
+	return newBaseResponse(http.StatusRequestEntityTooLarge, msg...)
+}
+func RequestURITooLong(msg ...string) *Response {
+	return newBaseResponse(http.StatusRequestURITooLong, msg...)
+}
+func UnsupportedMediaType(msg ...string) *Response {
+	return defaultResponder.UnsupportedMediaType(msg...)
+}
+func RequestedRangeNotSatisfiable(msg ...string) *Response {
+	return newBaseResponse(http.StatusRequestedRangeNotSatisfiable, msg...)
+}
+func ExpectationFailed(msg ...string) *Response {
+	return newBaseResponse(http.StatusExpectationFailed, msg...)
+}
+func Teapot(msg ...string) *Response {
+	return newBaseResponse(http.StatusTeapot, msg...)
+}
+func MisdirectedRequest(msg ...string) *Response {
+	return newBaseResponse(http.StatusMisdirectedRequest, msg...)
+}
 func UnprocessableEntity(msg ...string) *Response {
 	return newBaseResponse(http.StatusUnprocessableEntity, msg...)
 }
+func Locked(msg ...string) *Response {
+	return newBaseResponse(http.StatusLocked, msg...)
+}
+func FailedDependency(msg ...string) *Response {
+	return newBaseResponse(http.StatusFailedDependency, msg...)
+}
+func TooEarly(msg ...string) *Response {
+	return newBaseResponse(http.StatusTooEarly, msg...)
+}
+func UpgradeRequired(msg ...string) *Response {
+	return newBaseResponse(http.StatusUpgradeRequired, msg...)
+}
+func PreconditionRequired(msg ...string) *Response {
+	return newBaseResponse(http.StatusPreconditionRequired, msg...)
+}
 func TooManyRequests(msg ...string) *Response {
 	return newBaseResponse(http.StatusTooManyRequests, msg...)
 }
+func RequestHeaderFieldsTooLarge(msg ...string) *Response {
+	return newBaseResponse(http.StatusRequestHeaderFieldsTooLarge, msg...)
+}
+func UnavailableForLegalReasons(msg ...string) *Response {
+	return newBaseResponse(http.StatusUnavailableForLegalReasons, msg...)
+}
 func InternalServerError(msg ...string) *Response {
 	return newBaseResponse(http.StatusInternalServerError, msg...)
 }
@@ -99,6 +138,47 @@ func BadGateway(msg ...string) *Response {
 func ServiceUnavailable(msg ...string) *Response {
 	return newBaseResponse(http.StatusServiceUnavailable, msg...)
 }
+func GatewayTimeout(msg ...string) *Response {
+	return newBaseResponse(http.StatusGatewayTimeout, msg...)
+}
+func HTTPVersionNotSupported(msg ...string) *Response {
+	return newBaseResponse(http.StatusHTTPVersionNotSupported, msg...)
+}
+func VariantAlsoNegotiates(msg ...string) *Response {
+	return newBaseResponse(http.StatusVariantAlsoNegotiates, msg...)
+}
+func InsufficientStorage(msg ...string) *Response {
+	return newBaseResponse(http.StatusInsufficientStorage, msg...)
+}
+func LoopDetected(msg ...string) *Response {
+	return newBaseResponse(http.StatusLoopDetected, msg...)
+}
+func NotExtended(msg ...string) *Response {
+	return newBaseResponse(http.StatusNotExtended, msg...)
+}
+func NetworkAuthenticationRequired(msg ...string) *Response {
+	return newBaseResponse(http.StatusNetworkAuthenticationRequired, msg...)
+}
+func Status(code int, msg ...string) *Response {
+	return defaultResponder.Status(code, msg...)
+}
+
+// Redirect builders, the Location header is set to location
+func MovedPermanently(location string, msg ...string) *Response {
+	return defaultResponder.MovedPermanently(location, msg...)
+}
+func Found(location string, msg ...string) *Response {
+	return defaultResponder.Found(location, msg...)
+}
+func SeeOther(location string, msg ...string) *Response {
+	return defaultResponder.SeeOther(location, msg...)
+}
+func TemporaryRedirect(location string, msg ...string) *Response {
+	return defaultResponder.TemporaryRedirect(location, msg...)
+}
+func PermanentRedirect(location string, msg ...string) *Response {
+	return defaultResponder.PermanentRedirect(location, msg...)
+}
 
 func (r *Response) OK(msg ...string) *Response {
 	r.Code = http.StatusOK
@@ -150,21 +230,121 @@ func (r *Response) MethodNotAllowed(msg ...string) *Response {
 	r.applyMessage(msg...)
 	return r
 }
+func (r *Response) NotAcceptable(msg ...string) *Response {
+	r.Code = http.StatusNotAcceptable
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) ProxyAuthRequired(msg ...string) *Response {
+	r.Code = http.StatusProxyAuthRequired
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) RequestTimeout(msg ...string) *Response {
+	r.Code = http.StatusRequestTimeout
+	r.applyMessage(msg...)
+	return r
+}
 func (r *Response) Conflict(msg ...string) *Response {
 	r.Code = http.StatusConflict
 	r.applyMessage(msg...)
 	return r
 }
+func (r *Response) Gone(msg ...string) *Response {
+	r.Code = http.StatusGone
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) LengthRequired(msg ...string) *Response {
+	r.Code = http.StatusLengthRequired
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) PreconditionFailed(msg ...string) *Response {
+	r.Code = http.StatusPreconditionFailed
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) RequestEntityTooLarge(msg ...string) *Response {
+	r.Code = http.StatusRequestEntityTooLarge
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) RequestURITooLong(msg ...string) *Response {
+	r.Code = http.StatusRequestURITooLong
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) UnsupportedMediaType(msg ...string) *Response {
+	r.Code = http.StatusUnsupportedMediaType
+	r.applyMessage(msg...)
+	return r.withSupportedMediaTypes()
+}
+func (r *Response) RequestedRangeNotSatisfiable(msg ...string) *Response {
+	r.Code = http.StatusRequestedRangeNotSatisfiable
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) ExpectationFailed(msg ...string) *Response {
+	r.Code = http.StatusExpectationFailed
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) Teapot(msg ...string) *Response {
+	r.Code = http.StatusTeapot
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) MisdirectedRequest(msg ...string) *Response {
+	r.Code = http.StatusMisdirectedRequest
+	r.applyMessage(msg...)
+	return r
+}
 func (r *Response) UnprocessableEntity(msg ...string) *Response {
 	r.Code = http.StatusUnprocessableEntity
 	r.applyMessage(msg...)
 	return r
 }
+func (r *Response) Locked(msg ...string) *Response {
+	r.Code = http.StatusLocked
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) FailedDependency(msg ...string) *Response {
+	r.Code = http.StatusFailedDependency
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) TooEarly(msg ...string) *Response {
+	r.Code = http.StatusTooEarly
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) UpgradeRequired(msg ...string) *Response {
+	r.Code = http.StatusUpgradeRequired
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) PreconditionRequired(msg ...string) *Response {
+	r.Code = http.StatusPreconditionRequired
+	r.applyMessage(msg...)
+	return r
+}
 func (r *Response) TooManyRequests(msg ...string) *Response {
 	r.Code = http.StatusTooManyRequests
 	r.applyMessage(msg...)
 	return r
 }
+func (r *Response) RequestHeaderFieldsTooLarge(msg ...string) *Response {
+	r.Code = http.StatusRequestHeaderFieldsTooLarge
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) UnavailableForLegalReasons(msg ...string) *Response {
+	r.Code = http.StatusUnavailableForLegalReasons
+	r.applyMessage(msg...)
+	return r
+}
 func (r *Response) InternalServerError(msg ...string) *Response {
 	r.Code = http.StatusInternalServerError
 	r.applyMessage(msg...)
@@ -185,3 +365,74 @@ func (r *Response) ServiceUnavailable(msg ...string) *Response {
 	r.applyMessage(msg...)
 	return r
 }
+func (r *Response) GatewayTimeout(msg ...string) *Response {
+	r.Code = http.StatusGatewayTimeout
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) HTTPVersionNotSupported(msg ...string) *Response {
+	r.Code = http.StatusHTTPVersionNotSupported
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) VariantAlsoNegotiates(msg ...string) *Response {
+	r.Code = http.StatusVariantAlsoNegotiates
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) InsufficientStorage(msg ...string) *Response {
+	r.Code = http.StatusInsufficientStorage
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) LoopDetected(msg ...string) *Response {
+	r.Code = http.StatusLoopDetected
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) NotExtended(msg ...string) *Response {
+	r.Code = http.StatusNotExtended
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) NetworkAuthenticationRequired(msg ...string) *Response {
+	r.Code = http.StatusNetworkAuthenticationRequired
+	r.applyMessage(msg...)
+	return r
+}
+
+func (r *Response) NotModified(msg ...string) *Response {
+	r.Code = http.StatusNotModified
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) MovedPermanently(location string, msg ...string) *Response {
+	r.Code = http.StatusMovedPermanently
+	r.WithLocation(location)
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) Found(location string, msg ...string) *Response {
+	r.Code = http.StatusFound
+	r.WithLocation(location)
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) SeeOther(location string, msg ...string) *Response {
+	r.Code = http.StatusSeeOther
+	r.WithLocation(location)
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) TemporaryRedirect(location string, msg ...string) *Response {
+	r.Code = http.StatusTemporaryRedirect
+	r.WithLocation(location)
+	r.applyMessage(msg...)
+	return r
+}
+func (r *Response) PermanentRedirect(location string, msg ...string) *Response {
+	r.Code = http.StatusPermanentRedirect
+	r.WithLocation(location)
+	r.applyMessage(msg...)
+	return r
+}
@@ -0,0 +1,98 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// BatchItem is the result of a single operation in a batch
+type BatchItem struct {
+	ID      string        `json:"id,omitempty" xml:"id,omitempty"`
+	Code    int           `json:"code" xml:"code"`
+	Message string        `json:"message,omitempty" xml:"message,omitempty"`
+	Data    any           `json:"data,omitempty" xml:"data,omitempty"`
+	Errors  []ErrorDetail `json:"errors,omitempty" xml:"errors>error,omitempty"`
+}
+
+// BatchSummary aggregates the outcome of a batch
+type BatchSummary struct {
+	Total     int `json:"total" xml:"total"`
+	Succeeded int `json:"succeeded" xml:"succeeded"`
+	Failed    int `json:"failed" xml:"failed"`
+}
+
+// BatchResult is the Data of a 207 Multi-Status batch response
+type BatchResult struct {
+	Results []BatchItem  `json:"results" xml:"results>result"`
+	Summary BatchSummary `json:"summary" xml:"summary"`
+}
+
+// BatchResponse collects per-item results of a bulk operation
+// Items may be added concurrently
+type BatchResponse struct {
+	owner *Responder
+	items []BatchItem
+	mu    sync.Mutex
+}
+
+// Batch starts a batch response on the default Responder
+func Batch() *BatchResponse {
+	return defaultResponder.Batch()
+}
+
+// Batch starts a batch response on this Responder
+func (rs *Responder) Batch() *BatchResponse {
+	return &BatchResponse{owner: rs}
+}
+
+// Add records the outcome of an item from a response (code, message, data, errors)
+func (b *BatchResponse) Add(id string, item *Response) *BatchResponse {
+	return b.AddItem(BatchItem{
+		ID:      id,
+		Code:    item.Code,
+		Message: item.Message,
+		Data:    item.Data,
+		Errors:  item.Errors,
+	})
+}
+
+// AddItem records the outcome of an item
+func (b *BatchResponse) AddItem(item BatchItem) *BatchResponse {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.items = append(b.items, item)
+	return b
+}
+
+// Result returns the collected items and their summary
+// Items with a 2xx or 3xx code count as succeeded
+func (b *BatchResponse) Result() BatchResult {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := BatchResult{Results: make([]BatchItem, len(b.items))}
+	copy(result.Results, b.items)
+
+	for _, item := range b.items {
+		if item.Code >= 200 && item.Code < 400 {
+			result.Summary.Succeeded++
+		} else {
+			result.Summary.Failed++
+		}
+	}
+	result.Summary.Total = len(b.items)
+	return result
+}
+
+// Response builds the 207 Multi-Status envelope
+func (b *BatchResponse) Response() *Response {
+	result := b.Result()
+	msg := fmt.Sprintf("%d of %d operations succeeded", result.Summary.Succeeded, result.Summary.Total)
+	return b.owner.Status(http.StatusMultiStatus, msg).WithData(result)
+}
+
+// Send builds and sends the 207 Multi-Status envelope
+func (b *BatchResponse) Send(w http.ResponseWriter) error {
+	return b.Response().Send(w)
+}
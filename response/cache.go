@@ -0,0 +1,59 @@
+package response
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheOptions describes a Cache-Control policy
+// Durations are emitted when positive, use NoCache to require revalidation
+type CacheOptions struct {
+	Public               bool
+	Private              bool
+	NoCache              bool
+	NoStore              bool
+	NoTransform          bool
+	MustRevalidate       bool
+	ProxyRevalidate      bool
+	Immutable            bool
+	MaxAge               time.Duration
+	SMaxAge              time.Duration
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+}
+
+// String renders the Cache-Control header value
+func (o CacheOptions) String() string {
+	var directives []string
+	flag := func(set bool, name string) {
+		if set {
+			directives = append(directives, name)
+		}
+	}
+	seconds := func(d time.Duration, name string) {
+		if d > 0 {
+			directives = append(directives, name+"="+strconv.FormatInt(int64(d/time.Second), 10))
+		}
+	}
+
+	flag(o.Public, "public")
+	flag(o.Private, "private")
+	flag(o.NoCache, "no-cache")
+	flag(o.NoStore, "no-store")
+	flag(o.NoTransform, "no-transform")
+	flag(o.MustRevalidate, "must-revalidate")
+	flag(o.ProxyRevalidate, "proxy-revalidate")
+	flag(o.Immutable, "immutable")
+	seconds(o.MaxAge, "max-age")
+	seconds(o.SMaxAge, "s-maxage")
+	seconds(o.StaleWhileRevalidate, "stale-while-revalidate")
+	seconds(o.StaleIfError, "stale-if-error")
+
+	return strings.Join(directives, ", ")
+}
+
+// WithCacheControl sets the Cache-Control header from typed options
+func (r *Response) WithCacheControl(opts CacheOptions) *Response {
+	return r.WithHeader("Cache-Control", opts.String())
+}
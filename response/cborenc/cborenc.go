@@ -0,0 +1,76 @@
+// Package cborenc provides a CBOR encoder and decoder for response envelopes.
+package cborenc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// MediaType is the media type the codec is registered under
+const MediaType = "application/cbor"
+
+var (
+	encMode, _ = cbor.CoreDetEncOptions().EncMode()
+	decMode, _ = cbor.DecOptions{DefaultMapType: reflect.TypeOf(map[string]any(nil))}.DecMode()
+)
+
+// Encoder renders the envelope as CBOR
+// The envelope is converted through JSON first so it has the same keys and
+// options as the JSON encoding, and Data types only need json tags
+var Encoder = response.EncoderFunc(func(w io.Writer, r *response.Response) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	return encMode.NewEncoder(w).Encode(numbers(v))
+})
+
+// numbers turns JSON numbers into CBOR integers when they are whole, floats
+// otherwise
+func numbers(v any) any {
+	switch x := v.(type) {
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return n
+		}
+		f, _ := x.Float64()
+		return f
+	case map[string]any:
+		for k, item := range x {
+			x[k] = numbers(item)
+		}
+	case []any:
+		for i, item := range x {
+			x[i] = numbers(item)
+		}
+	}
+	return v
+}
+
+// Decoder parses CBOR envelopes, used by response.ExtractData
+var Decoder = response.DecoderFunc(func(data []byte, v any) error {
+	return decMode.Unmarshal(data, v)
+})
+
+// Register adds the CBOR encoder to the given Responder and the CBOR decoder
+// to ExtractData
+func Register(rs ...*response.Responder) {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+	target.RegisterEncoder(MediaType, Encoder)
+	response.RegisterDecoder(MediaType, Decoder)
+}
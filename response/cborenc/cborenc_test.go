@@ -0,0 +1,94 @@
+package cborenc
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+func TestRoundTrip(t *testing.T) {
+	type order struct {
+		ID    int    `json:"id"`
+		Owner string `json:"owner"`
+	}
+
+	rs := response.NewResponder(response.Config{})
+	Register(rs)
+
+	tests := []struct {
+		name string
+		resp *response.Response
+		want order
+	}{
+		{"created", rs.Created("order created").WithData(order{ID: 7, Owner: "ada"}), order{ID: 7, Owner: "ada"}},
+		{"not found", rs.NotFound("no such order"), order{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, message := tt.resp.Code, tt.resp.Message
+			w := httptest.NewRecorder()
+			if err := tt.resp.WithContentType(MediaType).Send(w); err != nil {
+				t.Fatal(err)
+			}
+			if got := w.Header().Get("Content-Type"); got != MediaType {
+				t.Fatalf("Content-Type = %q, want %q", got, MediaType)
+			}
+
+			var data order
+			decoded, err := response.ExtractData(w.Result(), &data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Code != code || decoded.Message != message {
+				t.Fatalf("decoded %d %q, want %d %q", decoded.Code, decoded.Message, code, message)
+			}
+			if data != tt.want {
+				t.Fatalf("data = %+v, want %+v", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoderOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  response.Config
+		absent  []string
+		present []string
+	}{
+		{"defaults", response.Config{}, []string{"result"}, []string{"code", "data", "timestamp"}},
+		{"omit code", response.Config{OmitCode: true}, []string{"code"}, []string{"data", "timestamp"}},
+		{"renamed data", response.Config{FieldNames: map[string]string{"data": "result"}}, []string{"data"}, []string{"code", "result"}},
+		{"structured trace", response.Config{StructuredTrace: true, IncludeSuccess: true}, nil, []string{"success", "trace"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := response.NewResponder(tt.config)
+			Register(rs)
+
+			body, err := rs.OK("listed").WithData(map[string]int{"total": 3}).AddTrace("cache miss").
+				WithContentType(MediaType).Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields map[string]any
+			if err := Decoder(body, &fields); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, key := range tt.absent {
+				if _, ok := fields[key]; ok {
+					t.Errorf("envelope has %q: %v", key, fields)
+				}
+			}
+			for _, key := range tt.present {
+				if _, ok := fields[key]; !ok {
+					t.Errorf("envelope lacks %q: %v", key, fields)
+				}
+			}
+		})
+	}
+}
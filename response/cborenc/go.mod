@@ -0,0 +1,12 @@
+module github.com/MintzyG/FastUtilitiesNet/response/cborenc
+
+go 1.25.3
+
+replace github.com/MintzyG/FastUtilitiesNet => ../..
+
+require (
+	github.com/MintzyG/FastUtilitiesNet v0.0.0-00010101000000-000000000000
+	github.com/fxamacker/cbor/v2 v2.9.2
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
@@ -0,0 +1,177 @@
+// Package cloudeventintercept publishes responses as CloudEvents to a
+// pluggable Sink, so other systems can react to API outcomes without handlers
+// knowing about the message bus. Sinks for NATS and Kafka live in the natssink
+// and kafkasink packages.
+//
+// Select which responses are published with the registration options of the
+// response package, and publish off the request path with response.Async:
+//
+//	rs.AddNamedInterceptor("events", cloudeventintercept.New(sink),
+//		response.OnlyStatus(201, 204), response.Async(5*time.Second))
+package cloudeventintercept
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/uuid"
+)
+
+// Sink delivers events to a message bus
+type Sink interface {
+	Publish(ctx context.Context, e event.Event) error
+}
+
+// SinkFunc adapts a function to a Sink
+type SinkFunc func(ctx context.Context, e event.Event) error
+
+func (f SinkFunc) Publish(ctx context.Context, e event.Event) error {
+	return f(ctx, e)
+}
+
+// Extension attributes set on every event
+const (
+	ExtensionStatusCode = "statuscode"
+	ExtensionModule     = "module"
+	ExtensionErrorCode  = "errorcode"
+	ExtensionTraceID    = "traceid"
+)
+
+// Publisher is a response interceptor turning responses into events
+type Publisher struct {
+	sink       Sink
+	source     string
+	typePrefix string
+	onError    func(error)
+}
+
+// Option configures a Publisher
+type Option func(*Publisher)
+
+// WithSource sets the event source, "/fastutilities/response" by default
+func WithSource(source string) Option {
+	return func(p *Publisher) {
+		p.source = source
+	}
+}
+
+// WithTypePrefix sets the prefix of event types, "fastutilities.response" by
+// default. The type is the prefix followed by the status class, such as
+// "fastutilities.response.server_error"
+func WithTypePrefix(prefix string) Option {
+	return func(p *Publisher) {
+		p.typePrefix = prefix
+	}
+}
+
+// WithErrorHandler is called when an event cannot be built or published.
+// Errors are logged with the standard logger by default
+func WithErrorHandler(fn func(error)) Option {
+	return func(p *Publisher) {
+		p.onError = fn
+	}
+}
+
+// New creates a Publisher delivering to sink
+func New(sink Sink, opts ...Option) *Publisher {
+	p := &Publisher{
+		sink:       sink,
+		source:     "/fastutilities/response",
+		typePrefix: "fastutilities.response",
+		onError: func(err error) {
+			log.Printf("cloudeventintercept: %v", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *Publisher) Intercept(ctx context.Context, resp *response.Response, statusCode int) {
+	p.publish(ctx, nil, resp, statusCode)
+}
+
+func (p *Publisher) InterceptSimple(resp *response.Response, statusCode int) {
+	p.publish(context.Background(), nil, resp, statusCode)
+}
+
+// InterceptRequest uses the request path as the event subject
+func (p *Publisher) InterceptRequest(req *http.Request, resp *response.Response, statusCode int) {
+	p.publish(req.Context(), req, resp, statusCode)
+}
+
+// Event builds the event for a response without publishing it. The data is
+// the envelope as clients receive it, after environment stripping and redaction
+func (p *Publisher) Event(req *http.Request, resp *response.Response, statusCode int) (event.Event, error) {
+	e := event.New()
+	e.SetID(uuid.NewString())
+	e.SetSource(p.source)
+	e.SetType(p.typePrefix + "." + statusClass(statusCode))
+
+	subject := resp.Module
+	if req != nil {
+		subject = req.URL.Path
+	}
+	e.SetSubject(subject)
+
+	ts := resp.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	e.SetTime(ts)
+
+	e.SetExtension(ExtensionStatusCode, statusCode)
+	e.SetExtension(ExtensionModule, resp.Module)
+	if resp.ErrorCode != "" {
+		e.SetExtension(ExtensionErrorCode, resp.ErrorCode)
+	}
+	if resp.TraceID != "" {
+		e.SetExtension(ExtensionTraceID, resp.TraceID)
+	}
+
+	// Consumers see what a client would, without production internals
+	if err := e.SetData(event.ApplicationJSON, resp.Exposed()); err != nil {
+		return e, err
+	}
+	return e, e.Validate()
+}
+
+func (p *Publisher) publish(ctx context.Context, req *http.Request, resp *response.Response, statusCode int) {
+	e, err := p.Event(req, resp, statusCode)
+	if err != nil {
+		p.onError(err)
+		return
+	}
+	if err := p.sink.Publish(ctx, e); err != nil {
+		p.onError(err)
+	}
+}
+
+func statusClass(statusCode int) string {
+	switch {
+	case statusCode >= 500:
+		return "server_error"
+	case statusCode >= 400:
+		return "client_error"
+	case statusCode >= 300:
+		return "redirect"
+	case statusCode >= 200:
+		return "success"
+	}
+	return "informational"
+}
+
+// Register adds a Publisher delivering to sink to the given Responder,
+// publishing in the background with the given timeout per event
+func Register(sink Sink, timeout time.Duration, opts []Option, rs ...*response.Responder) error {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+	return target.AddNamedInterceptor("cloudevents", New(sink, opts...), response.Async(timeout))
+}
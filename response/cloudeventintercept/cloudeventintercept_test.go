@@ -0,0 +1,99 @@
+package cloudeventintercept
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+func TestPublisher(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     *response.Response
+		status   int
+		req      *http.Request
+		wantType string
+		subject  string
+	}{
+		{"created", response.Created("order created").WithModule("orders"), http.StatusCreated, nil, "shop.success", "orders"},
+		{"request subject", response.OK().WithModule("orders"), http.StatusOK, httptest.NewRequest(http.MethodGet, "/orders/7", nil), "shop.success", "/orders/7"},
+		{"client error", response.Conflict("locked").WithModule("orders").WithErrorCode("ORDER_LOCKED"), http.StatusConflict, nil, "shop.client_error", "orders"},
+		{"server error", response.InternalServerError().WithModule("billing"), http.StatusInternalServerError, nil, "shop.server_error", "billing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var published []event.Event
+			sink := SinkFunc(func(_ context.Context, e event.Event) error {
+				published = append(published, e)
+				return nil
+			})
+			p := New(sink, WithTypePrefix("shop"), WithErrorHandler(func(err error) { t.Fatal(err) }))
+
+			if tt.req != nil {
+				p.InterceptRequest(tt.req, tt.resp, tt.status)
+			} else {
+				p.Intercept(context.Background(), tt.resp, tt.status)
+			}
+			if len(published) != 1 {
+				t.Fatalf("published %d events, want 1", len(published))
+			}
+
+			e := published[0]
+			if e.Type() != tt.wantType || e.Subject() != tt.subject {
+				t.Fatalf("event = %s %s, want %s %s", e.Type(), e.Subject(), tt.wantType, tt.subject)
+			}
+			if got := e.Extensions()[ExtensionStatusCode]; got != int32(tt.status) {
+				t.Fatalf("%s = %v, want %d", ExtensionStatusCode, got, tt.status)
+			}
+			if got, _ := e.Extensions()[ExtensionErrorCode].(string); got != tt.resp.ErrorCode {
+				t.Fatalf("%s = %q, want %q", ExtensionErrorCode, got, tt.resp.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestPublisherSinkError(t *testing.T) {
+	failure := errors.New("bus unavailable")
+	var reported error
+	p := New(SinkFunc(func(context.Context, event.Event) error { return failure }),
+		WithErrorHandler(func(err error) { reported = err }))
+
+	p.InterceptSimple(response.OK(), http.StatusOK)
+	if !errors.Is(reported, failure) {
+		t.Fatalf("reported %v, want %v", reported, failure)
+	}
+}
+
+func TestPublisherExposedData(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment response.Environment
+		wantTrace   bool
+	}{
+		{"development", response.EnvDevelopment, true},
+		{"production", response.EnvProduction, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := response.NewResponder(response.Config{Environment: tt.environment})
+			resp := rs.InternalServerError("boom").AddTrace("dial tcp 10.0.0.5:5432: connection refused")
+
+			e, err := New(SinkFunc(func(context.Context, event.Event) error { return nil })).
+				Event(nil, resp, http.StatusInternalServerError)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if traced := strings.Contains(string(e.Data()), "10.0.0.5"); traced != tt.wantTrace {
+				t.Fatalf("trace published = %v, want %v: %s", traced, tt.wantTrace, e.Data())
+			}
+		})
+	}
+}
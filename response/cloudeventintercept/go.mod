@@ -0,0 +1,25 @@
+module github.com/MintzyG/FastUtilitiesNet/response/cloudeventintercept
+
+go 1.25.3
+
+replace github.com/MintzyG/FastUtilitiesNet => ../..
+
+require (
+	github.com/MintzyG/FastUtilitiesNet v0.0.0-00010101000000-000000000000
+	github.com/cloudevents/sdk-go/v2 v2.15.2
+	github.com/google/uuid v1.6.0
+	github.com/nats-io/nats.go v1.48.0
+	github.com/segmentio/kafka-go v0.4.50
+)
+
+require (
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/nats-io/nkeys v0.4.11 // indirect
+	github.com/nats-io/nuid v1.0.1 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	golang.org/x/crypto v0.52.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
@@ -0,0 +1,41 @@
+// Package kafkasink publishes CloudEvents to Kafka in structured mode
+package kafkasink
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/segmentio/kafka-go"
+)
+
+// Sink writes every event as a JSON CloudEvent keyed by its subject, so
+// events about the same resource land on the same partition
+type Sink struct {
+	Writer *kafka.Writer
+}
+
+// New creates a sink writing with w. The topic is taken from w
+func New(w *kafka.Writer) *Sink {
+	return &Sink{Writer: w}
+}
+
+func (s *Sink) Publish(ctx context.Context, e event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return s.Writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(e.Subject()),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: "content-type", Value: []byte(event.ApplicationCloudEventsJSON)},
+		},
+	})
+}
+
+// Close closes the underlying writer
+func (s *Sink) Close() error {
+	return s.Writer.Close()
+}
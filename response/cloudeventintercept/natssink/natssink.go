@@ -0,0 +1,39 @@
+// Package natssink publishes CloudEvents to NATS in structured mode
+package natssink
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/nats-io/nats.go"
+)
+
+// Sink publishes every event as a JSON CloudEvent on Subject
+type Sink struct {
+	Conn    *nats.Conn
+	Subject string
+}
+
+// New creates a sink publishing on subject over conn
+func New(conn *nats.Conn, subject string) *Sink {
+	return &Sink{Conn: conn, Subject: subject}
+}
+
+// Publish hands the event to the connection. NATS core publishing does not
+// wait for the server, so ctx is only checked before sending
+func (s *Sink) Publish(ctx context.Context, e event.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(s.Subject)
+	msg.Header.Set("Content-Type", event.ApplicationCloudEventsJSON)
+	msg.Data = data
+	return s.Conn.PublishMsg(msg)
+}
@@ -1,6 +1,10 @@
 package response
 
-import "sync"
+import (
+	"hash"
+	"net/http"
+	"time"
+)
 
 type Config struct {
 	MaxTraceSize         int
@@ -9,6 +13,35 @@ type Config struct {
 	DefaultContentType   string
 	EnableSizeValidation bool
 	DefaultModule        string
+	XMLRootName          string // root element used by the XML encoder
+	CaptureStacks        bool   // record a stack trace on 5xx responses
+	Environment          Environment
+	CookieSameSite       http.SameSite     // applied to cookies without SameSite
+	CookieSecure         bool              // force the Secure attribute on cookies
+	ETagHash             func() hash.Hash  // hash used for computed ETags, SHA-256 if nil
+	WeakETags            bool              // computed ETags are weak validators
+	DefaultPage          int               // page used when the query has none
+	DefaultLimit         int               // page size used when the query has none
+	MaxLimit             int               // largest page size a client may request
+	StructuredTrace      bool              // serialize trace entries as objects instead of strings
+	MinTraceLevel        TraceLevel        // entries below this level are dropped, all are kept if zero
+	MaxTraceBytes        int               // total size of the trace lines, unlimited if zero
+	RedactData           bool              // apply redaction rules to Data as well
+	TraceCallers         bool              // record the file:line of each trace call in its entry
+	AsyncWorkers         int               // workers running async interceptors
+	AsyncQueueSize       int               // async interceptor calls waiting for a worker before dropping
+	OnInterceptorError   func(err error)   // receives interceptor, late panic, drop and Handler send errors, logged if nil
+	FieldNames           map[string]string // renames envelope keys when encoding, e.g. "data": "result"
+	Now                  func() time.Time  // clock for timestamps and timers, time.Now if nil
+	IncludeSuccess       bool              // add a "success" field, true for 2xx and 3xx
+	RequestIDExtractor   RequestIDFunc     // reads request IDs from the context, RequestIDFromContext if nil
+	APIVersion           string            // version reported when the request does not ask for one
+	APIVersions          []string          // versions clients may request, any if empty
+	ErrorDocsURL         string            // docs_url base for error codes, "{code}" is replaced by the code
+	OmitCode             bool              // leave the status code out of the body, it is in the status line
+	OmitTimestamp        bool              // leave the timestamp out of the body
+	OmitTrace            bool              // leave the trace out of the body
+	EnvelopeDisabled     bool              // send only Data, as with Raw
 }
 
 // Default configuration values
@@ -19,25 +52,23 @@ var defaultConfig = Config{
 	DefaultContentType:   "application/json",
 	EnableSizeValidation: true,
 	DefaultModule:        "GoResponse",
+	XMLRootName:          "response",
+	DefaultPage:          1,
+	DefaultLimit:         20,
+	MaxLimit:             100,
+	AsyncWorkers:         4,
+	AsyncQueueSize:       1024,
 }
 
-// Global configuration (thread-safe)
-var (
-	globalConfig   Config
-	globalConfigMu sync.RWMutex
-)
-
-// Initialize with default config
-func init() {
-	globalConfig = defaultConfig
+// SetConfig updates the configuration of this Responder
+func (rs *Responder) SetConfig(config Config) {
+	_ = rs.updateConfig(func(Config) (Config, error) {
+		return normalizeConfig(config), nil
+	})
 }
 
-// SetConfig updates the global configuration
-func SetConfig(config Config) {
-	globalConfigMu.Lock()
-	defer globalConfigMu.Unlock()
-
-	// Validate config values
+// normalizeConfig replaces out-of-range values with the library defaults
+func normalizeConfig(config Config) Config {
 	if config.MaxTraceSize <= 0 {
 		config.MaxTraceSize = defaultConfig.MaxTraceSize
 	}
@@ -50,32 +81,70 @@ func SetConfig(config Config) {
 	if config.DefaultContentType == "" {
 		config.DefaultContentType = defaultConfig.DefaultContentType
 	}
+	if config.XMLRootName == "" {
+		config.XMLRootName = defaultConfig.XMLRootName
+	}
+	if config.DefaultPage <= 0 {
+		config.DefaultPage = defaultConfig.DefaultPage
+	}
+	if config.DefaultLimit <= 0 {
+		config.DefaultLimit = defaultConfig.DefaultLimit
+	}
+	if config.MaxLimit <= 0 {
+		config.MaxLimit = defaultConfig.MaxLimit
+	}
+	if config.AsyncWorkers <= 0 {
+		config.AsyncWorkers = defaultConfig.AsyncWorkers
+	}
+	if config.AsyncQueueSize <= 0 {
+		config.AsyncQueueSize = defaultConfig.AsyncQueueSize
+	}
+	return config
+}
+
+// now reads the configured clock
+func (c Config) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
 
-	globalConfig = config
+// GetConfig returns a copy of the configuration of this Responder
+func (rs *Responder) GetConfig() Config {
+	rs.configMu.RLock()
+	defer rs.configMu.RUnlock()
+	return rs.config
+}
+
+// SetConfig updates the global configuration
+func SetConfig(config Config) {
+	defaultResponder.SetConfig(config)
 }
 
 // GetConfig returns a copy of the current global configuration
 func GetConfig() Config {
-	globalConfigMu.RLock()
-	defer globalConfigMu.RUnlock()
-	return globalConfig
+	return defaultResponder.GetConfig()
 }
 
-// getConfig is a helper to get current config (internal use)
-func getConfig() Config {
-	globalConfigMu.RLock()
-	defer globalConfigMu.RUnlock()
-	return globalConfig
+// Config returns the configuration the response is encoded with, its own or
+// its Responder's
+func (r *Response) Config() Config {
+	return r.getResponseConfig()
 }
 
 // getResponseConfig returns the config for this specific response
-// Falls back to global config if no specific config is set
+// Falls back to the owning Responder config if no specific config is set
 func (r *Response) getResponseConfig() Config {
-	// Check if this response has a specific config set
-	// We detect this by checking if any field differs from zero value
-	if r.config.MaxTraceSize > 0 || r.config.ResponseSizeLimit > 0 ||
-		r.config.MaxInterceptorAmount > 0 || r.config.DefaultContentType != "" {
+	if r.hasOwnConfig() {
 		return r.config
 	}
-	return getConfig()
+	return r.responder().GetConfig()
+}
+
+// hasOwnConfig reports whether this response has a specific config set
+// We detect this by checking if any field differs from zero value
+func (r *Response) hasOwnConfig() bool {
+	return r.config.MaxTraceSize > 0 || r.config.ResponseSizeLimit > 0 ||
+		r.config.MaxInterceptorAmount > 0 || r.config.DefaultContentType != ""
 }
@@ -0,0 +1,52 @@
+package response
+
+import "context"
+
+type configKey struct{}
+
+// ContextWithConfig stores a configuration in ctx. Responses sent with that
+// context, through SendWithContext or SendWithRequest, use it instead of their
+// Responder's configuration, so middleware can apply per-request policies such
+// as verbose traces for requests carrying a debug header. Traces are filtered
+// as they are added, so handlers call WithContext right after the builder for
+// MinTraceLevel and the trace limits to apply. A configuration set on the
+// response itself with WithConfig still takes precedence
+func ContextWithConfig(ctx context.Context, config Config) context.Context {
+	return context.WithValue(ctx, configKey{}, normalizeConfig(config))
+}
+
+// ConfigFromContext returns the configuration stored by ContextWithConfig
+func ConfigFromContext(ctx context.Context) (Config, bool) {
+	if ctx == nil {
+		return Config{}, false
+	}
+	config, ok := ctx.Value(configKey{}).(Config)
+	return config, ok
+}
+
+// WithContext adopts the configuration stored in ctx by ContextWithConfig
+// right away instead of when the response is sent, so the traces added
+// afterwards follow its MinTraceLevel, MaxTraceSize and MaxTraceBytes
+//
+//	response.OK().WithContext(req.Context()).AddDebugTrace("cache miss")
+func (r *Response) WithContext(ctx context.Context) *Response {
+	r.applyContextConfig(ctx)
+	return r
+}
+
+// applyContextConfig adopts the configuration stored in ctx, unless the
+// response carries its own
+func (r *Response) applyContextConfig(ctx context.Context) {
+	if r.hasOwnConfig() {
+		return
+	}
+	config, ok := ConfigFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if r.ContentType == "" || r.ContentType == r.responder().GetConfig().DefaultContentType {
+		r.ContentType = config.DefaultContentType
+	}
+	r.config = config
+}
@@ -0,0 +1,32 @@
+package response
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithContext(t *testing.T) {
+	rs := NewResponder(Config{MinTraceLevel: TraceLevelWarn})
+	verbose := ContextWithConfig(context.Background(), Config{MinTraceLevel: TraceLevelDebug})
+
+	tests := []struct {
+		name   string
+		build  func() *Response
+		ctx    context.Context
+		traces int
+	}{
+		{"responder config", func() *Response { return rs.OK() }, context.Background(), 0},
+		{"context config", func() *Response { return rs.OK() }, verbose, 1},
+		{"base response", func() *Response { return rs.Base() }, verbose, 1},
+		{"own config wins", func() *Response { return rs.OK().WithConfig(Config{MinTraceLevel: TraceLevelError}) }, verbose, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.build().WithContext(tt.ctx).AddDebugTrace("cache miss")
+			if len(r.Trace) != tt.traces {
+				t.Fatalf("got %d traces, want %d: %v", len(r.Trace), tt.traces, r.Trace)
+			}
+		})
+	}
+}
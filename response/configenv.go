@@ -0,0 +1,152 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// envSetting maps one environment variable, named without its prefix, onto
+// a Config field
+type envSetting struct {
+	name string
+	set  func(c *Config, value string) error
+}
+
+var envSettings = []envSetting{
+	{"MAX_TRACE_SIZE", envInt(1, func(c *Config) *int { return &c.MaxTraceSize })},
+	{"SIZE_LIMIT", envInt(1, func(c *Config) *int { return &c.ResponseSizeLimit })},
+	{"SIZE_VALIDATION", envBool(func(c *Config) *bool { return &c.EnableSizeValidation })},
+	{"MAX_INTERCEPTORS", envInt(1, func(c *Config) *int { return &c.MaxInterceptorAmount })},
+	{"CONTENT_TYPE", envString(func(c *Config) *string { return &c.DefaultContentType })},
+	{"DEFAULT_MODULE", envString(func(c *Config) *string { return &c.DefaultModule })},
+	{"XML_ROOT_NAME", envString(func(c *Config) *string { return &c.XMLRootName })},
+	{"CAPTURE_STACKS", envBool(func(c *Config) *bool { return &c.CaptureStacks })},
+	{"ENVIRONMENT", envEnvironment},
+	{"COOKIE_SAME_SITE", envSameSite},
+	{"COOKIE_SECURE", envBool(func(c *Config) *bool { return &c.CookieSecure })},
+	{"WEAK_ETAGS", envBool(func(c *Config) *bool { return &c.WeakETags })},
+	{"DEFAULT_PAGE", envInt(1, func(c *Config) *int { return &c.DefaultPage })},
+	{"DEFAULT_LIMIT", envInt(1, func(c *Config) *int { return &c.DefaultLimit })},
+	{"MAX_LIMIT", envInt(1, func(c *Config) *int { return &c.MaxLimit })},
+	{"STRUCTURED_TRACE", envBool(func(c *Config) *bool { return &c.StructuredTrace })},
+	{"MIN_TRACE_LEVEL", envTraceLevel},
+	{"MAX_TRACE_BYTES", envInt(0, func(c *Config) *int { return &c.MaxTraceBytes })},
+	{"REDACT_DATA", envBool(func(c *Config) *bool { return &c.RedactData })},
+	{"TRACE_CALLERS", envBool(func(c *Config) *bool { return &c.TraceCallers })},
+	{"INCLUDE_SUCCESS", envBool(func(c *Config) *bool { return &c.IncludeSuccess })},
+	{"API_VERSION", envString(func(c *Config) *string { return &c.APIVersion })},
+	{"ERROR_DOCS_URL", envString(func(c *Config) *string { return &c.ErrorDocsURL })},
+	{"OMIT_CODE", envBool(func(c *Config) *bool { return &c.OmitCode })},
+	{"OMIT_TIMESTAMP", envBool(func(c *Config) *bool { return &c.OmitTimestamp })},
+	{"OMIT_TRACE", envBool(func(c *Config) *bool { return &c.OmitTrace })},
+	{"ENVELOPE_DISABLED", envBool(func(c *Config) *bool { return &c.EnvelopeDisabled })},
+	{"ASYNC_WORKERS", envInt(1, func(c *Config) *int { return &c.AsyncWorkers })},
+	{"ASYNC_QUEUE_SIZE", envInt(1, func(c *Config) *int { return &c.AsyncQueueSize })},
+}
+
+// LoadConfigFromEnv applies the environment variables named prefix_SETTING,
+// such as GORESPONSE_MAX_TRACE_SIZE or GORESPONSE_SIZE_LIMIT, on top of the
+// current configuration. The prefix defaults to GORESPONSE. Unset variables
+// keep their setting. If any variable is invalid nothing is applied and every
+// problem is returned as a *ConfigError joined into one error
+func (rs *Responder) LoadConfigFromEnv(prefix string) error {
+	if prefix == "" {
+		prefix = "GORESPONSE"
+	}
+
+	return rs.updateConfig(func(config Config) (Config, error) {
+		var errs []error
+		for _, s := range envSettings {
+			key := prefix + "_" + s.name
+			value, ok := os.LookupEnv(key)
+			if !ok {
+				continue
+			}
+			if err := s.set(&config, strings.TrimSpace(value)); err != nil {
+				errs = append(errs, &ConfigError{Field: key, Msg: err.Error()})
+			}
+		}
+		if len(errs) > 0 {
+			return config, errors.Join(errs...)
+		}
+		return normalizeConfig(config), nil
+	})
+}
+
+// LoadConfigFromEnv applies environment variables to the global configuration
+func LoadConfigFromEnv(prefix string) error {
+	return defaultResponder.LoadConfigFromEnv(prefix)
+}
+
+func envInt(min int, field func(*Config) *int) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		if n < min {
+			return fmt.Errorf("must be at least %d, got %d", min, n)
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+func envBool(field func(*Config) *bool) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+func envString(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		if value == "" {
+			return errors.New("must not be empty")
+		}
+		*field(c) = value
+		return nil
+	}
+}
+
+func envEnvironment(c *Config, value string) error {
+	switch env := Environment(strings.ToLower(value)); env {
+	case EnvDevelopment, EnvStaging, EnvProduction:
+		c.Environment = env
+		return nil
+	}
+	return fmt.Errorf("%q is not one of development, staging, production", value)
+}
+
+func envSameSite(c *Config, value string) error {
+	switch strings.ToLower(value) {
+	case "default":
+		c.CookieSameSite = http.SameSiteDefaultMode
+	case "lax":
+		c.CookieSameSite = http.SameSiteLaxMode
+	case "strict":
+		c.CookieSameSite = http.SameSiteStrictMode
+	case "none":
+		c.CookieSameSite = http.SameSiteNoneMode
+	default:
+		return fmt.Errorf("%q is not one of default, lax, strict, none", value)
+	}
+	return nil
+}
+
+func envTraceLevel(c *Config, value string) error {
+	var level TraceLevel
+	if err := level.UnmarshalText([]byte(strings.ToLower(value))); err != nil {
+		return fmt.Errorf("%q is not one of debug, info, warn, error", value)
+	}
+	c.MinTraceLevel = level
+	return nil
+}
@@ -0,0 +1,37 @@
+package response
+
+// OnConfigChange registers fn to be called after every configuration change
+// of this Responder, through SetConfig, SetConfigStrict, Configure or
+// LoadConfigFromEnv, so interceptors and encoders caching derived values can
+// refresh them. Listeners run synchronously on the goroutine that changed the
+// configuration and may read it with GetConfig
+func (rs *Responder) OnConfigChange(fn func(old, new Config)) {
+	rs.configMu.Lock()
+	defer rs.configMu.Unlock()
+	rs.configListeners = append(rs.configListeners, fn)
+}
+
+// OnConfigChange registers a listener on the global configuration
+func OnConfigChange(fn func(old, new Config)) {
+	defaultResponder.OnConfigChange(fn)
+}
+
+// updateConfig replaces the configuration with the result of update and
+// notifies the listeners. Nothing changes when update returns an error
+func (rs *Responder) updateConfig(update func(current Config) (Config, error)) error {
+	rs.configMu.Lock()
+	old := rs.config
+	config, err := update(old)
+	if err != nil {
+		rs.configMu.Unlock()
+		return err
+	}
+	rs.config = config
+	listeners := rs.configListeners
+	rs.configMu.Unlock()
+
+	for _, fn := range listeners {
+		fn(old, config)
+	}
+	return nil
+}
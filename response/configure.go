@@ -0,0 +1,154 @@
+package response
+
+import "time"
+
+// ConfigOption changes one setting of a Config. Options only touch what they
+// set, so unlike SetConfig a zero value never resets another setting
+type ConfigOption func(*Config)
+
+// Configure applies options on top of the current configuration of this
+// Responder
+func (rs *Responder) Configure(opts ...ConfigOption) {
+	_ = rs.updateConfig(func(config Config) (Config, error) {
+		for _, opt := range opts {
+			opt(&config)
+		}
+		return normalizeConfig(config), nil
+	})
+}
+
+// Configure applies options on top of the global configuration
+func Configure(opts ...ConfigOption) {
+	defaultResponder.Configure(opts...)
+}
+
+// WithMaxTrace sets the maximum number of trace lines per response
+func WithMaxTrace(n int) ConfigOption {
+	return func(c *Config) {
+		c.MaxTraceSize = n
+	}
+}
+
+// WithSizeLimit sets the maximum encoded response size in bytes
+func WithSizeLimit(bytes int) ConfigOption {
+	return func(c *Config) {
+		c.ResponseSizeLimit = bytes
+	}
+}
+
+// WithSizeValidation turns the response size check on or off
+func WithSizeValidation(enabled bool) ConfigOption {
+	return func(c *Config) {
+		c.EnableSizeValidation = enabled
+	}
+}
+
+// WithMaxInterceptors sets how many interceptors may be registered
+func WithMaxInterceptors(n int) ConfigOption {
+	return func(c *Config) {
+		c.MaxInterceptorAmount = n
+	}
+}
+
+// WithContentType sets the content type used when a response has none
+func WithContentType(contentType string) ConfigOption {
+	return func(c *Config) {
+		c.DefaultContentType = contentType
+	}
+}
+
+// WithDefaultModule sets the module of responses that do not name one
+func WithDefaultModule(module string) ConfigOption {
+	return func(c *Config) {
+		c.DefaultModule = module
+	}
+}
+
+// WithEnvironment sets how much debugging information responses expose
+func WithEnvironment(env Environment) ConfigOption {
+	return func(c *Config) {
+		c.Environment = env
+	}
+}
+
+// WithCaptureStacks turns stack capture on 5xx responses on or off
+func WithCaptureStacks(enabled bool) ConfigOption {
+	return func(c *Config) {
+		c.CaptureStacks = enabled
+	}
+}
+
+// WithPageLimits sets the default and maximum page sizes
+func WithPageLimits(defaultLimit, maxLimit int) ConfigOption {
+	return func(c *Config) {
+		c.DefaultLimit = defaultLimit
+		c.MaxLimit = maxLimit
+	}
+}
+
+// WithTraceLimits sets the minimum trace level kept and the total size of the
+// trace lines, unlimited if zero
+func WithTraceLimits(minLevel TraceLevel, maxBytes int) ConfigOption {
+	return func(c *Config) {
+		c.MinTraceLevel = minLevel
+		c.MaxTraceBytes = maxBytes
+	}
+}
+
+// WithFieldNames renames envelope keys when encoding, mapping the default
+// names such as "data" or "message" to the ones clients expect
+func WithFieldNames(names map[string]string) ConfigOption {
+	return func(c *Config) {
+		c.FieldNames = names
+	}
+}
+
+// WithSuccessField adds a "success" field computed from the status class
+func WithSuccessField(enabled bool) ConfigOption {
+	return func(c *Config) {
+		c.IncludeSuccess = enabled
+	}
+}
+
+// WithAPIVersions sets the version reported by default and the versions
+// clients may request through APIVersionMiddleware
+func WithAPIVersions(defaultVersion string, supported ...string) ConfigOption {
+	return func(c *Config) {
+		c.APIVersion = defaultVersion
+		c.APIVersions = supported
+	}
+}
+
+// WithErrorDocsURL sets the base of the docs_url reported with error codes,
+// such as "https://docs.example.com/errors/{code}"
+func WithErrorDocsURL(base string) ConfigOption {
+	return func(c *Config) {
+		c.ErrorDocsURL = base
+	}
+}
+
+// WithOmittedFields leaves the status code, timestamp or trace out of encoded
+// envelopes, for API guidelines that forbid duplicating them in the body
+func WithOmittedFields(code, timestamp, trace bool) ConfigOption {
+	return func(c *Config) {
+		c.OmitCode = code
+		c.OmitTimestamp = timestamp
+		c.OmitTrace = trace
+	}
+}
+
+// WithClock sets the clock used for timestamps and timers, so tests and
+// golden files get deterministic envelopes
+func WithClock(now func() time.Time) ConfigOption {
+	return func(c *Config) {
+		c.Now = now
+	}
+}
+
+// WithAsync sets the async interceptor worker count and queue size
+func WithAsync(workers, queueSize int) ConfigOption {
+	return func(c *Config) {
+		c.AsyncWorkers = workers
+		c.AsyncQueueSize = queueSize
+	}
+}
@@ -0,0 +1,103 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// Validate reports every out-of-range value as a *ConfigError, joined into
+// one error. It is nil for a valid configuration. Unlike SetConfig, zero
+// values are not replaced with defaults, so a valid Config is best built from
+// GetConfig
+func (c Config) Validate() error {
+	var errs []error
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, &ConfigError{Field: field, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	positive := []struct {
+		field string
+		value int
+	}{
+		{"MaxTraceSize", c.MaxTraceSize},
+		{"ResponseSizeLimit", c.ResponseSizeLimit},
+		{"MaxInterceptorAmount", c.MaxInterceptorAmount},
+		{"DefaultPage", c.DefaultPage},
+		{"DefaultLimit", c.DefaultLimit},
+		{"MaxLimit", c.MaxLimit},
+		{"AsyncWorkers", c.AsyncWorkers},
+		{"AsyncQueueSize", c.AsyncQueueSize},
+	}
+	for _, p := range positive {
+		if p.value <= 0 {
+			fail(p.field, "must be positive, got %d", p.value)
+		}
+	}
+
+	if c.DefaultLimit > c.MaxLimit && c.MaxLimit > 0 {
+		fail("DefaultLimit", "must not exceed MaxLimit (%d), got %d", c.MaxLimit, c.DefaultLimit)
+	}
+	if c.MaxTraceBytes < 0 {
+		fail("MaxTraceBytes", "must not be negative, got %d", c.MaxTraceBytes)
+	}
+	if c.DefaultContentType == "" {
+		fail("DefaultContentType", "must not be empty")
+	}
+	if c.XMLRootName == "" {
+		fail("XMLRootName", "must not be empty")
+	}
+
+	switch c.Environment {
+	case "", EnvDevelopment, EnvStaging, EnvProduction:
+	default:
+		fail("Environment", "unknown environment %q", c.Environment)
+	}
+	if c.MinTraceLevel < 0 || c.MinTraceLevel > TraceLevelError {
+		fail("MinTraceLevel", "unknown trace level %d", c.MinTraceLevel)
+	}
+	if c.CookieSameSite < 0 || c.CookieSameSite > http.SameSiteNoneMode {
+		fail("CookieSameSite", "unknown SameSite mode %d", c.CookieSameSite)
+	}
+
+	if c.APIVersion != "" && len(c.APIVersions) > 0 && !slices.Contains(c.APIVersions, c.APIVersion) {
+		fail("APIVersion", "%q is not one of the supported versions %v", c.APIVersion, c.APIVersions)
+	}
+
+	renamed := make(map[string]string, len(c.FieldNames))
+	keys := envelopeKeys()
+	for from, to := range c.FieldNames {
+		if to == "" {
+			fail("FieldNames", "%q is renamed to an empty name", from)
+			continue
+		}
+		if other, ok := renamed[to]; ok {
+			fail("FieldNames", "%q and %q are both renamed to %q", min(from, other), max(from, other), to)
+		}
+		renamed[to] = from
+		if _, moved := c.FieldNames[to]; to != from && !moved && slices.Contains(keys, to) {
+			fail("FieldNames", "%q is renamed to %q, which the envelope already uses", from, to)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SetConfigStrict is like SetConfig but rejects an invalid configuration
+// instead of replacing invalid values with defaults. The current
+// configuration is kept when an error is returned
+func (rs *Responder) SetConfigStrict(config Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	return rs.updateConfig(func(Config) (Config, error) {
+		return config, nil
+	})
+}
+
+// SetConfigStrict validates and updates the global configuration
+func SetConfigStrict(config Config) error {
+	return defaultResponder.SetConfigStrict(config)
+}
@@ -0,0 +1,36 @@
+package response
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateFieldNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   map[string]string
+		wantErr string
+	}{
+		{"rename", map[string]string{"data": "result", "trace": "debug"}, ""},
+		{"swap", map[string]string{"data": "message", "message": "data"}, ""},
+		{"empty name", map[string]string{"trace": ""}, `"trace" is renamed to an empty name`},
+		{"existing key", map[string]string{"data": "message"}, `"data" is renamed to "message", which the envelope already uses`},
+		{"computed key", map[string]string{"data": "success"}, `which the envelope already uses`},
+		{"shared target", map[string]string{"data": "body", "message": "body"}, `"data" and "message" are both renamed to "body"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewResponder().GetConfig()
+			config.FieldNames = tt.names
+
+			err := config.Validate()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Validate() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("Validate() = %v, want an error containing %s", err, tt.wantErr)
+			}
+		})
+	}
+}
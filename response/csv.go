@@ -0,0 +1,156 @@
+package response
+
+import (
+	"context"
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const mediaTypeCSV = "text/csv"
+
+// CSVEncoder renders tabular Data as CSV, dropping the rest of the envelope
+// Data must be a [][]string or a slice of structs (or struct pointers), in which
+// case the header row uses the json field names. With Config.RedactData the
+// cells are redacted, the first row being taken as the column names
+// It is not registered by default, register it to negotiate text/csv
+var CSVEncoder = EncoderFunc(func(w io.Writer, r *Response) error {
+	rows, err := csvRows(r.Data)
+	if err != nil {
+		return err
+	}
+
+	if r.getResponseConfig().RedactData && len(rows) > 0 {
+		// Data may be the [][]string itself, which must stay untouched
+		rd := r.responder().getRedactions()
+		rows = slices.Clone(rows)
+		for i := 1; i < len(rows); i++ {
+			rows[i] = slices.Clone(rows[i])
+			rd.row(rows[0], rows[i])
+		}
+	}
+
+	return csv.NewWriter(w).WriteAll(rows)
+})
+
+// SendCSV sends the Data as a CSV download, the optional filename is set in
+// the Content-Disposition header. Message and trace are not sent
+func (r *Response) SendCSV(w http.ResponseWriter, filename ...string) error {
+	return r.SendCSVWithContext(context.Background(), w, filename...)
+}
+
+// SendCSVWithContext is like SendCSV but passes ctx to the interceptors
+func (r *Response) SendCSVWithContext(ctx context.Context, w http.ResponseWriter, filename ...string) error {
+	defer r.Release()
+
+	r.runInterceptors(ctx, nil)
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := r.renderWith(buf, CSVEncoder); err != nil {
+		return r.sendFallback(ctx, nil, w, buf, err)
+	}
+
+	if len(filename) > 0 && filename[0] != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename[0]}))
+	}
+	r.ContentType = mediaTypeCSV + "; charset=utf-8"
+	return r.write(w, buf.Bytes())
+}
+
+// csvRows converts tabular data into CSV records
+func csvRows(data any) ([][]string, error) {
+	if rows, ok := data.([][]string); ok {
+		return rows, nil
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("csv: unsupported data type %T", data)
+	}
+
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: unsupported element type %s", v.Type().Elem())
+	}
+
+	header, fields := csvColumns(elem)
+	rows := make([][]string, 0, v.Len()+1)
+	rows = append(rows, header)
+
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		if item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				continue
+			}
+			item = item.Elem()
+		}
+
+		row := make([]string, len(fields))
+		for j, idx := range fields {
+			row[j] = csvValue(item.Field(idx))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// csvColumns returns the header names and field indexes of the exported fields
+func csvColumns(t reflect.Type) ([]string, []int) {
+	var header []string
+	var fields []int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+	return header, fields
+}
+
+// csvValue formats a single cell
+func csvValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+	}
+
+	switch x := v.Interface().(type) {
+	case encoding.TextMarshaler:
+		text, err := x.MarshalText()
+		if err == nil {
+			return string(text)
+		}
+	case fmt.Stringer:
+		return x.String()
+	}
+
+	if v.Kind() == reflect.Pointer {
+		return csvValue(v.Elem())
+	}
+	return fmt.Sprint(v.Interface())
+}
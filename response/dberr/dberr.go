@@ -0,0 +1,179 @@
+// Package dberr maps database errors to responses.
+// Drivers exposing SQLSTATE codes through a SQLState() method (pgx, lib/pq)
+// are understood out of the box, other drivers plug in a Classifier.
+package dberr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// Kind is the category of a database error
+type Kind int
+
+const (
+	Unknown Kind = iota
+	NotFound
+	UniqueViolation
+	ForeignKeyViolation
+	ConstraintViolation
+	Conflict
+	Canceled
+	Timeout
+	Unavailable
+)
+
+// StatusClientClosedRequest is the non-standard status used for canceled requests
+const StatusClientClosedRequest = 499
+
+// Classifier categorizes an error, returning Unknown for errors it doesn't know
+type Classifier func(err error) Kind
+
+// Thread-safe classifiers registry
+var (
+	classifiers   []Classifier
+	classifiersMu sync.RWMutex
+)
+
+// RegisterClassifier adds a driver specific classifier
+// Classifiers registered later are consulted first
+func RegisterClassifier(c Classifier) {
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+	classifiers = append(classifiers, c)
+}
+
+// Classify returns the kind of a database error
+func Classify(err error) Kind {
+	if err == nil {
+		return Unknown
+	}
+
+	classifiersMu.RLock()
+	current := make([]Classifier, len(classifiers))
+	copy(current, classifiers)
+	classifiersMu.RUnlock()
+
+	for i := len(current) - 1; i >= 0; i-- {
+		if kind := current[i](err); kind != Unknown {
+			return kind
+		}
+	}
+	if kind := classifyStd(err); kind != Unknown {
+		return kind
+	}
+	return classifySQLState(err)
+}
+
+// classifyStd handles the database/sql and context errors
+func classifyStd(err error) Kind {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return NotFound
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	case errors.Is(err, sql.ErrConnDone), errors.Is(err, driver.ErrBadConn):
+		return Unavailable
+	}
+	return Unknown
+}
+
+// classifySQLState handles drivers exposing SQLSTATE codes (pgx, lib/pq)
+func classifySQLState(err error) Kind {
+	var stateErr interface{ SQLState() string }
+	if !errors.As(err, &stateErr) {
+		return Unknown
+	}
+
+	switch state := stateErr.SQLState(); state {
+	case "23505":
+		return UniqueViolation
+	case "23503":
+		return ForeignKeyViolation
+	case "23502", "23514":
+		return ConstraintViolation
+	case "40001", "40P01":
+		return Conflict
+	case "57014":
+		return Timeout
+	default:
+		// Class 08 - connection exception
+		if strings.HasPrefix(state, "08") {
+			return Unavailable
+		}
+	}
+	return Unknown
+}
+
+// ToResponse builds the response for a database error on the given Responder,
+// or the default one. Unknown errors become InternalServerError, nil returns nil.
+// The driver error is traced for server errors only, since it names tables and
+// constraints that client errors such as unique violations must not expose
+func ToResponse(err error, rs ...*response.Responder) *response.Response {
+	if err == nil {
+		return nil
+	}
+
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+
+	resp := build(target, Classify(err))
+	if resp.Code >= 500 {
+		resp.AddPrefixedTrace("database", err)
+	}
+	return resp
+}
+
+// Register makes FromError (and Handler) map database errors on the given
+// Responder, or the default one. Context cancellations and deadlines keep the
+// Responder's own mappings, they rarely come from the database when they
+// reach a handler. FromError traces the error for server errors only
+func Register(rs ...*response.Responder) {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+
+	target.RegisterErrorMatcher(
+		func(err error) bool {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return false
+			}
+			return Classify(err) != Unknown
+		},
+		func(err error) *response.Response {
+			return build(target, Classify(err))
+		},
+	)
+}
+
+// build creates the response for a kind of error
+func build(rs *response.Responder, kind Kind) *response.Response {
+	switch kind {
+	case NotFound:
+		return rs.NotFound("Resource not found")
+	case UniqueViolation:
+		return rs.Conflict("Resource already exists")
+	case ForeignKeyViolation:
+		return rs.Conflict("Related resource constraint violated")
+	case ConstraintViolation:
+		return rs.UnprocessableEntity("Resource constraint violated")
+	case Conflict:
+		return rs.Conflict("Concurrent update conflict, retry the request")
+	case Canceled:
+		return rs.Status(StatusClientClosedRequest, "Request canceled")
+	case Timeout, Unavailable:
+		return rs.ServiceUnavailable("Database unavailable")
+	}
+	return rs.InternalServerError("Internal server error")
+}
@@ -0,0 +1,77 @@
+package dberr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+type stateError struct {
+	state string
+	msg   string
+}
+
+func (e *stateError) Error() string    { return e.msg }
+func (e *stateError) SQLState() string { return e.state }
+
+func TestToResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  int
+		wantTrace bool
+	}{
+		{"unique violation", &stateError{"23505", `duplicate key violates unique constraint "users_email_key"`}, http.StatusConflict, false},
+		{"foreign key violation", &stateError{"23503", `insert on table "orders" violates foreign key "orders_user_id_fkey"`}, http.StatusConflict, false},
+		{"check violation", &stateError{"23514", `new row for relation "users" violates check constraint "age_positive"`}, http.StatusUnprocessableEntity, false},
+		{"connection failure", &stateError{"08006", "connection to 10.0.0.5 lost"}, http.StatusServiceUnavailable, true},
+		{"unknown", errors.New("syntax error at or near users"), http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := ToResponse(tt.err)
+			if resp.Code != tt.wantCode {
+				t.Fatalf("Code = %d, want %d", resp.Code, tt.wantCode)
+			}
+			traced := strings.Contains(strings.Join(resp.Trace, "\n"), tt.err.Error())
+			if traced != tt.wantTrace {
+				t.Fatalf("driver error traced = %v, want %v: %q", traced, tt.wantTrace, resp.Trace)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	rs := response.NewResponder(response.Config{Environment: response.EnvProduction})
+	Register(rs)
+
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  int
+		wantTrace bool
+	}{
+		{"unique violation", fmt.Errorf("create user: %w", &stateError{"23505", `duplicate key violates unique constraint "users_email_key"`}), http.StatusConflict, false},
+		{"connection failure", &stateError{"08006", "connection to 10.0.0.5 lost"}, http.StatusServiceUnavailable, true},
+		{"upstream timeout", fmt.Errorf("call billing: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := rs.FromError(tt.err)
+			if resp.Code != tt.wantCode {
+				t.Fatalf("Code = %d, want %d", resp.Code, tt.wantCode)
+			}
+			traced := strings.Contains(strings.Join(resp.Trace, "\n"), tt.err.Error())
+			if traced != tt.wantTrace {
+				t.Fatalf("error traced = %v, want %v: %q", traced, tt.wantTrace, resp.Trace)
+			}
+		})
+	}
+}
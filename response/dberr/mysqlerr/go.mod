@@ -0,0 +1,18 @@
+module github.com/MintzyG/FastUtilitiesNet/response/dberr/mysqlerr
+
+go 1.25.3
+
+replace (
+	github.com/MintzyG/FastUtilitiesNet => ../../..
+	github.com/MintzyG/FastUtilitiesNet/response/dberr => ..
+)
+
+require (
+	github.com/MintzyG/FastUtilitiesNet/response/dberr v0.0.0-00010101000000-000000000000
+	github.com/go-sql-driver/mysql v1.9.3
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/MintzyG/FastUtilitiesNet v0.0.0-00010101000000-000000000000 // indirect
+)
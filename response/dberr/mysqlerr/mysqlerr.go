@@ -0,0 +1,54 @@
+// Package mysqlerr teaches dberr about go-sql-driver/mysql errors.
+package mysqlerr
+
+import (
+	"errors"
+
+	"github.com/MintzyG/FastUtilitiesNet/response/dberr"
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL server error numbers
+const (
+	errDupEntry          = 1062
+	errRowIsReferenced   = 1451
+	errNoReferencedRow   = 1452
+	errBadNull           = 1048
+	errCheckConstraint   = 3819
+	errLockDeadlock      = 1213
+	errLockWaitTimeout   = 1205
+	errQueryInterrupted  = 1317
+	errQueryTimeout      = 3024
+	errServerGone        = 2006
+	errServerLost        = 2013
+	errTooManyConnection = 1040
+)
+
+// Classify categorizes MySQL server errors
+func Classify(err error) dberr.Kind {
+	var myErr *mysql.MySQLError
+	if !errors.As(err, &myErr) {
+		return dberr.Unknown
+	}
+
+	switch myErr.Number {
+	case errDupEntry:
+		return dberr.UniqueViolation
+	case errRowIsReferenced, errNoReferencedRow:
+		return dberr.ForeignKeyViolation
+	case errBadNull, errCheckConstraint:
+		return dberr.ConstraintViolation
+	case errLockDeadlock:
+		return dberr.Conflict
+	case errLockWaitTimeout, errQueryInterrupted, errQueryTimeout:
+		return dberr.Timeout
+	case errServerGone, errServerLost, errTooManyConnection:
+		return dberr.Unavailable
+	}
+	return dberr.Unknown
+}
+
+// Register adds the MySQL classifier to dberr
+func Register() {
+	dberr.RegisterClassifier(Classify)
+}
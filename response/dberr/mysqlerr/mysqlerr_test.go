@@ -0,0 +1,35 @@
+package mysqlerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/MintzyG/FastUtilitiesNet/response/dberr"
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want dberr.Kind
+	}{
+		{"duplicate entry", &mysql.MySQLError{Number: 1062}, dberr.UniqueViolation},
+		{"wrapped foreign key", fmt.Errorf("insert order: %w", &mysql.MySQLError{Number: 1452}), dberr.ForeignKeyViolation},
+		{"null column", &mysql.MySQLError{Number: 1048}, dberr.ConstraintViolation},
+		{"deadlock", &mysql.MySQLError{Number: 1213}, dberr.Conflict},
+		{"lock wait timeout", &mysql.MySQLError{Number: 1205}, dberr.Timeout},
+		{"too many connections", &mysql.MySQLError{Number: 1040}, dberr.Unavailable},
+		{"unmapped number", &mysql.MySQLError{Number: 1146}, dberr.Unknown},
+		{"other error", errors.New("boom"), dberr.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Fatalf("Classify(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
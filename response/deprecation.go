@@ -0,0 +1,41 @@
+package response
+
+import (
+	"net/http"
+	"time"
+)
+
+// DeprecationInfo announces the retirement of an endpoint in the envelope
+type DeprecationInfo struct {
+	Deprecated bool       `json:"deprecated" xml:"deprecated"`
+	Sunset     *time.Time `json:"sunset,omitempty" xml:"sunset,omitempty"`
+	Link       string     `json:"link,omitempty" xml:"link,omitempty"`
+}
+
+// WithDeprecation marks the endpoint as deprecated with the Deprecation,
+// Sunset (RFC 8594) and Link rel="deprecation" headers and a deprecation block
+// A zero sunset or an empty link are left out
+func (r *Response) WithDeprecation(sunset time.Time, link string) *Response {
+	info := &DeprecationInfo{Deprecated: true, Link: link}
+	r.WithHeader("Deprecation", "true")
+
+	if !sunset.IsZero() {
+		s := sunset.UTC()
+		info.Sunset = &s
+		r.WithHeader("Sunset", s.Format(http.TimeFormat))
+	}
+	if link != "" {
+		r.AddHeader("Link", linkValue(link, "deprecation"))
+	}
+
+	r.Deprecation = info
+	return r
+}
+
+// WithReplacement points to the successor of a resource that is gone or has
+// migrated, with a Link rel="successor-version" header and a replacement field
+// Usually combined with Gone()
+func (r *Response) WithReplacement(url string) *Response {
+	r.Replacement = url
+	return r.AddHeader("Link", linkValue(url, "successor-version"))
+}
@@ -0,0 +1,135 @@
+package response
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Encoder renders a response envelope in a specific media type
+type Encoder interface {
+	Encode(w io.Writer, r *Response) error
+}
+
+// EncoderFunc adapts a function into an Encoder
+type EncoderFunc func(w io.Writer, r *Response) error
+
+func (f EncoderFunc) Encode(w io.Writer, r *Response) error {
+	return f(w, r)
+}
+
+const (
+	mediaTypeJSON  = "application/json"
+	mediaTypeXML   = "application/xml"
+	mediaTypePlain = "text/plain"
+)
+
+var jsonEncoder = EncoderFunc(func(w io.Writer, r *Response) error {
+	return json.NewEncoder(w).Encode(r)
+})
+
+// xmlEncoder writes the envelope under the configured root element
+// Data must be XML-marshalable (maps are not supported by encoding/xml)
+var xmlEncoder = EncoderFunc(func(w io.Writer, r *Response) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	root := xml.StartElement{Name: xml.Name{Local: r.getResponseConfig().XMLRootName}}
+	if err := xml.NewEncoder(w).EncodeElement(r, root); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+})
+
+// plainEncoder writes "CODE message" followed by one line per error and trace entry
+// The status text is used when the response has no message
+var plainEncoder = EncoderFunc(func(w io.Writer, r *Response) error {
+	message := r.Message
+	if message == "" {
+		message = http.StatusText(r.Code)
+	}
+
+	if _, err := fmt.Fprintf(w, "%d %s\n", r.Code, message); err != nil {
+		return err
+	}
+	for _, e := range r.Errors {
+		if _, err := fmt.Fprintln(w, "error:", e.Error()); err != nil {
+			return err
+		}
+	}
+	for _, t := range r.Trace {
+		if _, err := fmt.Fprintln(w, t); err != nil {
+			return err
+		}
+	}
+	return nil
+})
+
+// RegisterEncoder registers an encoder for a media type on this Responder
+// Responses whose content type matches the media type are rendered with it,
+// and it becomes a candidate for content negotiation
+func (rs *Responder) RegisterEncoder(mediaType string, enc Encoder) {
+	mt := normalizeMediaType(mediaType)
+
+	rs.encodersMu.Lock()
+	defer rs.encodersMu.Unlock()
+
+	if _, exists := rs.encoders[mt]; !exists {
+		rs.encoderOrder = append(rs.encoderOrder, mt)
+	}
+	rs.encoders[mt] = enc
+}
+
+// Encoders returns the registered media types in registration order
+func (rs *Responder) Encoders() []string {
+	rs.encodersMu.RLock()
+	defer rs.encodersMu.RUnlock()
+	result := make([]string, len(rs.encoderOrder))
+	copy(result, rs.encoderOrder)
+	return result
+}
+
+// RegisterEncoder registers an encoder on the default Responder
+func RegisterEncoder(mediaType string, enc Encoder) {
+	defaultResponder.RegisterEncoder(mediaType, enc)
+}
+
+// encoderFor picks the encoder for a content type
+// Structured syntax suffixes (e.g. +json) map to their base encoder and
+// unknown types fall back to JSON
+func (rs *Responder) encoderFor(contentType string) Encoder {
+	mt := normalizeMediaType(contentType)
+
+	rs.encodersMu.RLock()
+	defer rs.encodersMu.RUnlock()
+
+	if enc, ok := rs.encoders[mt]; ok {
+		return enc
+	}
+	if i := strings.LastIndex(mt, "+"); i >= 0 {
+		if enc, ok := rs.encoders["application/"+mt[i+1:]]; ok {
+			return enc
+		}
+	}
+	if enc, ok := rs.encoders[mediaTypeJSON]; ok {
+		return enc
+	}
+	return jsonEncoder
+}
+
+// normalizeMediaType strips parameters and lowercases a content type
+func normalizeMediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt, _, _ = strings.Cut(contentType, ";")
+		return strings.ToLower(strings.TrimSpace(mt))
+	}
+	return mt
+}
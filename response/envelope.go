@@ -0,0 +1,183 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// envelope has the default encoding of Response
+type envelope Response
+
+// decoratedEnvelope adds the computed fields enabled in the configuration.
+// Trace shadows the string trace, holding either the strings or their
+// structured entries, and Timestamp shadows the timestamp so it can be left
+// out
+type decoratedEnvelope struct {
+	Success *bool `json:"success,omitempty" xml:"success,omitempty"`
+	*envelope
+	Trace     any        `json:"trace,omitempty" xml:"trace>entry,omitempty"`
+	Timestamp *time.Time `json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+}
+
+// marshaled returns the value actually encoded for the response, without the
+// fields left out by OmitCode, OmitTimestamp and OmitTrace
+func (r *Response) marshaled() any {
+	config := r.getResponseConfig()
+	if config.OmitCode && r.Code != 0 {
+		c := *r
+		c.Code = 0
+		r = &c
+	}
+	if !config.StructuredTrace && !config.IncludeSuccess && !config.OmitTimestamp && !config.OmitTrace {
+		return (*envelope)(r)
+	}
+
+	env := decoratedEnvelope{envelope: (*envelope)(r)}
+	if config.IncludeSuccess {
+		success := r.Code >= 200 && r.Code < 400
+		env.Success = &success
+	}
+	if !config.OmitTimestamp {
+		env.Timestamp = &r.Timestamp
+	}
+	switch {
+	case len(r.Trace) == 0 || config.OmitTrace:
+	case config.StructuredTrace:
+		env.Trace = r.structuredTrace()
+	default:
+		env.Trace = r.Trace
+	}
+	return env
+}
+
+// structuredTrace returns the entries matching Trace. Lines appended to Trace
+// directly are reported without a timestamp or level
+func (r *Response) structuredTrace() []TraceEntry {
+	entries := make([]TraceEntry, len(r.Trace))
+	copy(entries, r.TraceEntries)
+	for i := range entries {
+		if entries[i].Message == "" {
+			entries[i].Message = r.Trace[i]
+		}
+	}
+	return entries
+}
+
+func (r *Response) MarshalJSON() ([]byte, error) {
+	if r.Data != nil && r.getResponseConfig().RedactData {
+		c := *r
+		c.Data = r.RedactedData()
+		r = &c
+	}
+
+	raw, err := json.Marshal(r.marshaled())
+	if err != nil {
+		return nil, err
+	}
+
+	names := r.getResponseConfig().FieldNames
+	if len(names) == 0 {
+		return raw, nil
+	}
+	return renameJSONFields(raw, names)
+}
+
+func (r *Response) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	names := r.getResponseConfig().FieldNames
+	if len(names) == 0 {
+		return e.EncodeElement(r.marshaled(), start)
+	}
+
+	var buf bytes.Buffer
+	if err := xml.NewEncoder(&buf).EncodeElement(r.marshaled(), start); err != nil {
+		return err
+	}
+	return renameXMLFields(e, &buf, names)
+}
+
+// renameJSONFields renames the top-level keys of a JSON object, keeping
+// their order
+func renameJSONFields(raw []byte, names map[string]string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(raw)+16))
+	out.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		key := tok.(string)
+		if renamed, ok := names[key]; ok {
+			key = renamed
+		}
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		out.Write(name)
+		out.WriteByte(':')
+		out.Write(value)
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
+
+// renameXMLFields copies an encoded element to e, renaming its direct children
+func renameXMLFields(e *xml.Encoder, r io.Reader, names map[string]string) error {
+	dec := xml.NewDecoder(r)
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if renamed, ok := names[t.Name.Local]; ok && depth == 2 {
+				t.Name.Local = renamed
+			}
+			tok = t
+		case xml.EndElement:
+			if renamed, ok := names[t.Name.Local]; ok && depth == 2 {
+				t.Name.Local = renamed
+			}
+			depth--
+			tok = t
+		}
+		if err := e.EncodeToken(tok); err != nil {
+			return err
+		}
+	}
+}
+
+// envelopeKeys lists the top-level keys the envelope can encode
+func envelopeKeys() []string {
+	keys := []string{"success"}
+	t := reflect.TypeFor[Response]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,65 @@
+package response
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestEnvelopeOmitFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		absent  []string
+		present []string
+	}{
+		{"defaults", Config{}, nil, []string{"code", "timestamp", "trace"}},
+		{"omit code", Config{OmitCode: true}, []string{"code"}, []string{"timestamp", "trace"}},
+		{"omit timestamp", Config{OmitTimestamp: true}, []string{"timestamp"}, []string{"code", "trace"}},
+		{"omit trace", Config{OmitTrace: true}, []string{"trace"}, []string{"code", "timestamp"}},
+		{"omit structured trace", Config{OmitTrace: true, StructuredTrace: true}, []string{"trace"}, []string{"code"}},
+		{"omit and rename", Config{OmitCode: true, FieldNames: map[string]string{"trace": "debug"}}, []string{"code", "trace"}, []string{"debug", "timestamp"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResponder(tt.config).BadRequest("invalid").AddTrace("parsing body")
+
+			raw, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := xml.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body := string(out)
+
+			for _, key := range tt.absent {
+				if _, ok := fields[key]; ok {
+					t.Errorf("JSON has %q: %s", key, raw)
+				}
+				if strings.Contains(body, "<"+key+">") {
+					t.Errorf("XML has %q: %s", key, body)
+				}
+			}
+			for _, key := range tt.present {
+				if _, ok := fields[key]; !ok {
+					t.Errorf("JSON lacks %q: %s", key, raw)
+				}
+				if !strings.Contains(body, "<"+key+">") {
+					t.Errorf("XML lacks %q: %s", key, body)
+				}
+			}
+			if r.Code == 0 || r.Timestamp.IsZero() || len(r.Trace) == 0 {
+				t.Fatal("encoding changed the response")
+			}
+		})
+	}
+}
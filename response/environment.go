@@ -0,0 +1,44 @@
+package response
+
+// Environment selects how much debugging information responses expose
+type Environment string
+
+const (
+	// EnvDevelopment exposes everything, it is used when no environment is set
+	EnvDevelopment Environment = "development"
+	// EnvStaging exposes traces but not stack traces
+	EnvStaging Environment = "staging"
+	// EnvProduction strips traces, stack traces and raw error strings from 5xx bodies
+	EnvProduction Environment = "production"
+)
+
+// Exposed returns the response as clients receive it, stripped for the
+// configured environment and redacted, for code that reports it through
+// another channel than Send
+func (r *Response) Exposed() *Response {
+	return r.exposed(r.getResponseConfig()).redacted()
+}
+
+// exposed returns the response as it should be encoded for the configured
+// environment. Stripping happens on a copy so interceptors still see everything
+func (r *Response) exposed(config Config) *Response {
+	switch config.Environment {
+	case EnvProduction:
+		if r.Code >= 500 && (r.Trace != nil || r.Stack != nil || r.Errors != nil) {
+			c := *r
+			c.Trace = nil
+			c.TraceEntries = nil
+			c.Stack = nil
+			c.Errors = nil
+			return &c
+		}
+		fallthrough
+	case EnvStaging:
+		if r.Stack != nil {
+			c := *r
+			c.Stack = nil
+			return &c
+		}
+	}
+	return r
+}
@@ -0,0 +1,111 @@
+package response
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ErrorCodeInfo describes a registered machine-readable error code
+type ErrorCodeInfo struct {
+	Code    string
+	Message string
+	Status  int
+	DocsURL string
+}
+
+// RegisterErrorCode adds an application error code to this Responder catalog
+func (rs *Responder) RegisterErrorCode(code, defaultMessage string, httpStatus int, docsURL string) error {
+	if err := validateStatusCode(httpStatus); err != nil {
+		return err
+	}
+
+	rs.errorCodesMu.Lock()
+	defer rs.errorCodesMu.Unlock()
+
+	if rs.errorCodes == nil {
+		rs.errorCodes = make(map[string]ErrorCodeInfo)
+	}
+	rs.errorCodes[code] = ErrorCodeInfo{
+		Code:    code,
+		Message: defaultMessage,
+		Status:  httpStatus,
+		DocsURL: docsURL,
+	}
+	return nil
+}
+
+// LookupErrorCode returns the catalog entry of a registered error code
+func (rs *Responder) LookupErrorCode(code string) (ErrorCodeInfo, bool) {
+	rs.errorCodesMu.RLock()
+	defer rs.errorCodesMu.RUnlock()
+	info, ok := rs.errorCodes[code]
+	return info, ok
+}
+
+// FromErrorCode builds a response from a registered error code
+// Unknown codes produce an InternalServerError carrying the code
+func (rs *Responder) FromErrorCode(code string) *Response {
+	info, ok := rs.LookupErrorCode(code)
+	if !ok {
+		return rs.InternalServerError().WithErrorCode(code)
+	}
+	return rs.newResponse(info.Status).WithErrorCode(code)
+}
+
+// WithErrorCode sets a machine-readable error code on the response
+// Registered codes also set the status, the docs URL and the message if none is set
+// Codes without a docs URL get one built from Config.ErrorDocsURL
+func (r *Response) WithErrorCode(code string) *Response {
+	r.ErrorCode = code
+
+	info, ok := r.responder().LookupErrorCode(code)
+	if !ok {
+		r.applyDocsURL()
+		return r
+	}
+
+	r.Code = info.Status
+	r.DocsURL = info.DocsURL
+	if r.Message == "" {
+		r.Message = info.Message
+	}
+	r.applyDocsURL()
+	return r
+}
+
+// applyDocsURL builds the docs URL of an error code from Config.ErrorDocsURL
+// unless one is already set, for instance by the error code catalog. It runs
+// again at send for a configuration adopted from the context
+func (r *Response) applyDocsURL() {
+	if r.ErrorCode == "" || r.DocsURL != "" {
+		return
+	}
+	if base := r.getResponseConfig().ErrorDocsURL; base != "" {
+		r.DocsURL = errorDocsURL(base, r.ErrorCode)
+	}
+}
+
+// errorDocsURL replaces "{code}" in base with the escaped code, or appends the
+// code as a path segment when base has no placeholder
+func errorDocsURL(base, code string) string {
+	code = url.PathEscape(code)
+	if strings.Contains(base, "{code}") {
+		return strings.ReplaceAll(base, "{code}", code)
+	}
+	return strings.TrimSuffix(base, "/") + "/" + code
+}
+
+// RegisterErrorCode adds an error code to the default Responder catalog
+func RegisterErrorCode(code, defaultMessage string, httpStatus int, docsURL string) error {
+	return defaultResponder.RegisterErrorCode(code, defaultMessage, httpStatus, docsURL)
+}
+
+// LookupErrorCode returns an error code registered on the default Responder
+func LookupErrorCode(code string) (ErrorCodeInfo, bool) {
+	return defaultResponder.LookupErrorCode(code)
+}
+
+// FromErrorCode builds a response from an error code registered on the default Responder
+func FromErrorCode(code string) *Response {
+	return defaultResponder.FromErrorCode(code)
+}
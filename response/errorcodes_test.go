@@ -0,0 +1,82 @@
+package response
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestErrorCodeDocsURL(t *testing.T) {
+	rs := NewResponder(Config{ErrorDocsURL: "https://docs.example.com/errors/{code}"})
+	if err := rs.RegisterErrorCode("ORDER_LOCKED", "order is locked", 409, "https://example.com/locked"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.RegisterErrorCode("ORDER_GONE", "order is gone", 410, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		build func() *Response
+		want  string
+	}{
+		{"catalog docs", func() *Response { return rs.FromErrorCode("ORDER_LOCKED") }, "https://example.com/locked"},
+		{"registered without docs", func() *Response { return rs.FromErrorCode("ORDER_GONE") }, "https://docs.example.com/errors/ORDER_GONE"},
+		{"unregistered", func() *Response { return rs.FromErrorCode("NO SUCH CODE") }, "https://docs.example.com/errors/NO%20SUCH%20CODE"},
+		{"on a builder", func() *Response { return rs.BadRequest().WithErrorCode("BAD_SKU") }, "https://docs.example.com/errors/BAD_SKU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.build()
+			if got := r.Exposed().DocsURL; got != tt.want {
+				t.Fatalf("Exposed().DocsURL = %q, want %q", got, tt.want)
+			}
+
+			raw, err := r.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var body struct {
+				DocsURL string `json:"docs_url"`
+			}
+			if err := json.Unmarshal(raw, &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.DocsURL != tt.want {
+				t.Fatalf("rendered docs_url = %q, want %q", body.DocsURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromErrorCodeStatus(t *testing.T) {
+	rs := NewResponder(Config{CaptureStacks: true})
+	if err := rs.RegisterErrorCode("LEDGER_DOWN", "ledger unavailable", 503, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.RegisterErrorCode("ORDER_LOCKED", "order is locked", 409, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		code      string
+		wantCode  int
+		wantStack bool
+	}{
+		{"LEDGER_DOWN", 503, true},
+		{"ORDER_LOCKED", 409, false},
+		{"UNREGISTERED", 500, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			r := rs.FromErrorCode(tt.code)
+			if r.Code != tt.wantCode {
+				t.Fatalf("Code = %d, want %d", r.Code, tt.wantCode)
+			}
+			if stack := len(r.Stack) > 0; stack != tt.wantStack {
+				t.Fatalf("stack captured = %v, want %v", stack, tt.wantStack)
+			}
+		})
+	}
+}
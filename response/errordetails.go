@@ -0,0 +1,87 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorDetail is a structured error entry in the envelope errors array
+// Unlike trace entries these are meant for API clients
+type ErrorDetail struct {
+	Code    string `json:"code,omitempty" xml:"code,omitempty"`
+	Field   string `json:"field,omitempty" xml:"field,omitempty"`
+	Message string `json:"message" xml:"message"`
+}
+
+func (e ErrorDetail) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("(%s) %s", e.Field, e.Message)
+	}
+	return e.Message
+}
+
+// WithErrors adds one structured error per non-nil error
+// ErrorDetail and ValidationError values keep their code and field
+func (r *Response) WithErrors(errs ...error) *Response {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		r.Errors = append(r.Errors, errorDetailFrom(err))
+	}
+	return r
+}
+
+// WithErrorDetails adds structured errors to the response
+func (r *Response) WithErrorDetails(details ...ErrorDetail) *Response {
+	r.Errors = append(r.Errors, details...)
+	return r
+}
+
+// errorDetailFrom converts an error into an ErrorDetail
+func errorDetailFrom(err error) ErrorDetail {
+	var detail ErrorDetail
+	if errors.As(err, &detail) {
+		return detail
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return ErrorDetail{
+			Field:   validationErr.Field,
+			Message: validationErr.Message,
+		}
+	}
+
+	return ErrorDetail{Message: err.Error()}
+}
+
+// WithJoinedError adds one structured error and one trace entry per leaf of
+// err, unwrapping errors.Join trees. A wrapped chain without joins stays a
+// single entry so the context added by the wrapping is kept
+func (r *Response) WithJoinedError(err error) *Response {
+	for _, leaf := range leafErrors(err) {
+		r.Errors = append(r.Errors, errorDetailFrom(leaf))
+		r.appendTraceInternal("error", leaf)
+	}
+	return r
+}
+
+// leafErrors flattens an error tree into its independent errors
+func leafErrors(err error) []error {
+	switch x := err.(type) {
+	case nil:
+		return nil
+	case interface{ Unwrap() []error }:
+		var leaves []error
+		for _, inner := range x.Unwrap() {
+			leaves = append(leaves, leafErrors(inner)...)
+		}
+		return leaves
+	case interface{ Unwrap() error }:
+		if inner := leafErrors(x.Unwrap()); len(inner) > 1 {
+			return inner
+		}
+	}
+	return []error{err}
+}
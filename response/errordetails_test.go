@@ -0,0 +1,38 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWithJoinedError(t *testing.T) {
+	errEmail := errors.New("email is taken")
+	errName := &ValidationError{Field: "name", Message: "is required"}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantCount int
+		wantTrace []string
+	}{
+		{"nil", nil, 0, nil},
+		{"single", errEmail, 1, []string{"error: email is taken"}},
+		{"joined", errors.Join(errEmail, errName), 2, []string{"error: email is taken", "error: " + errName.Error()}},
+		{"wrapped join", fmt.Errorf("signup: %w", errors.Join(errEmail, errName)), 2, []string{"error: email is taken", "error: " + errName.Error()}},
+		{"wrapped chain", fmt.Errorf("signup: %w", errEmail), 1, []string{"error: signup: email is taken"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResponder().BadRequest().WithJoinedError(tt.err)
+			if len(r.Errors) != tt.wantCount {
+				t.Fatalf("got %d errors, want %d: %+v", len(r.Errors), tt.wantCount, r.Errors)
+			}
+			if !reflect.DeepEqual(r.Trace, tt.wantTrace) {
+				t.Fatalf("Trace = %q, want %q", r.Trace, tt.wantTrace)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// errorMapping turns errors matching a sentinel or type into a response
+type errorMapping struct {
+	match func(err error) bool
+	build func(err error) *Response
+}
+
+// RegisterErrorMapping maps errors matching sentinel (as errors.Is would) to
+// the response built by builder. Later registrations take precedence and a
+// nil sentinel is ignored
+func (rs *Responder) RegisterErrorMapping(sentinel error, builder func() *Response) {
+	if sentinel == nil {
+		return
+	}
+
+	isComparable := reflect.TypeOf(sentinel).Comparable()
+	rs.addErrorMapping(errorMapping{
+		match: func(err error) bool {
+			if isComparable && err == sentinel {
+				return true
+			}
+			if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(sentinel) {
+				return true
+			}
+			return false
+		},
+		build: func(error) *Response {
+			return builder()
+		},
+	})
+}
+
+// RegisterErrorType maps errors of type T (as errors.As would) to the response
+// built by builder on the given Responder, or the default one
+func RegisterErrorType[T error](builder func(err T) *Response, rs ...*Responder) {
+	target := defaultResponder
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+
+	target.addErrorMapping(errorMapping{
+		match: func(err error) bool {
+			_, ok := err.(T)
+			return ok
+		},
+		build: func(err error) *Response {
+			return builder(err.(T))
+		},
+	})
+}
+
+// RegisterErrorMatcher maps errors for which match returns true to the response
+// built by builder, for mappings that are neither a sentinel nor a type
+func (rs *Responder) RegisterErrorMatcher(match func(err error) bool, builder func(err error) *Response) {
+	rs.addErrorMapping(errorMapping{match: match, build: builder})
+}
+
+func (rs *Responder) addErrorMapping(m errorMapping) {
+	rs.errorMappingsMu.Lock()
+	defer rs.errorMappingsMu.Unlock()
+	rs.errorMappings = append(rs.errorMappings, m)
+}
+
+// FromError builds the response for err using the registered mappings
+// A *Response found in the chain is returned as a clone, so responses kept in
+// variables and returned as errors are never changed by sending them, and
+// can be used by concurrent requests. Otherwise the chain is
+// walked from the outermost error inwards and the first error with a mapping
+// wins, unmapped errors become InternalServerError. The error is added to the
+// trace of server errors only, since its text is internal and client errors
+// keep their trace in production. Nil errors return nil
+func (rs *Responder) FromError(err error) *Response {
+	if err == nil {
+		return nil
+	}
+
+	var resp *Response
+	if errors.As(err, &resp) && resp != nil {
+		return resp.Clone()
+	}
+
+	rs.errorMappingsMu.RLock()
+	mappings := make([]errorMapping, len(rs.errorMappings))
+	copy(mappings, rs.errorMappings)
+	rs.errorMappingsMu.RUnlock()
+
+	resp = findErrorMapping(err, mappings)
+	if resp == nil {
+		resp = rs.InternalServerError("Internal server error")
+	}
+	if resp.Code >= 500 {
+		resp.appendTraceInternal("error", err)
+	}
+	return resp
+}
+
+// findErrorMapping walks the error tree depth-first looking for a mapping
+func findErrorMapping(err error, mappings []errorMapping) *Response {
+	if err == nil {
+		return nil
+	}
+
+	for i := len(mappings) - 1; i >= 0; i-- {
+		if mappings[i].match(err) {
+			return mappings[i].build(err)
+		}
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return findErrorMapping(x.Unwrap(), mappings)
+	case interface{ Unwrap() []error }:
+		for _, inner := range x.Unwrap() {
+			if resp := findErrorMapping(inner, mappings); resp != nil {
+				return resp
+			}
+		}
+	}
+	return nil
+}
+
+// registerDefaultErrorMappings maps the standard library errors every
+// Responder understands out of the box
+func (rs *Responder) registerDefaultErrorMappings() {
+	rs.RegisterErrorMapping(context.DeadlineExceeded, func() *Response {
+		return rs.GatewayTimeout("Request timed out")
+	})
+}
+
+// RegisterErrorMapping maps a sentinel error on the default Responder
+func RegisterErrorMapping(sentinel error, builder func() *Response) {
+	defaultResponder.RegisterErrorMapping(sentinel, builder)
+}
+
+// RegisterErrorMatcher maps matching errors on the default Responder
+func RegisterErrorMatcher(match func(err error) bool, builder func(err error) *Response) {
+	defaultResponder.RegisterErrorMatcher(match, builder)
+}
+
+// FromError builds the response for err using the default Responder mappings
+func FromError(err error) *Response {
+	return defaultResponder.FromError(err)
+}
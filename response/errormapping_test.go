@@ -0,0 +1,72 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegisterErrorMapping(t *testing.T) {
+	errMissing := errors.New("missing")
+
+	rs := NewResponder()
+	rs.RegisterErrorMapping(nil, func() *Response { return rs.Conflict() })
+	rs.RegisterErrorMapping(errMissing, func() *Response { return rs.NotFound("Missing") })
+
+	tests := []struct {
+		name   string
+		err    error
+		want   int
+		traced bool
+	}{
+		{"sentinel", errMissing, http.StatusNotFound, false},
+		{"wrapped sentinel", fmt.Errorf("lookup: %w", errMissing), http.StatusNotFound, false},
+		{"unmapped", errors.New("boom"), http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := rs.FromError(tt.err)
+			if resp.Code != tt.want {
+				t.Fatalf("Code = %d, want %d", resp.Code, tt.want)
+			}
+			if traced := len(resp.Trace) > 0; traced != tt.traced {
+				t.Fatalf("error traced = %v, want %v: %q", traced, tt.traced, resp.Trace)
+			}
+		})
+	}
+}
+
+func TestFromErrorClonesResponses(t *testing.T) {
+	rs := NewResponder()
+	errForbidden := rs.Forbidden("No access").WithHeader("X-Reason", "policy")
+
+	for _, id := range []string{"req-1", "req-2"} {
+		resp := rs.FromError(fmt.Errorf("handler: %w", errForbidden))
+		if resp == errForbidden {
+			t.Fatal("FromError returned the shared response")
+		}
+
+		w := httptest.NewRecorder()
+		if err := resp.SendWithContext(ContextWithRequestID(context.Background(), id), w); err != nil {
+			t.Fatal(err)
+		}
+		var body struct {
+			RequestID string `json:"request_id"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.RequestID != id {
+			t.Fatalf("request_id = %q, want %q", body.RequestID, id)
+		}
+	}
+
+	if errForbidden.RequestID != "" {
+		t.Fatal("sending the clone changed the shared response")
+	}
+}
@@ -10,8 +10,10 @@ var (
 	ErrValidationFailed  = errors.New("validation failed")
 	ErrSizeLimitExceeded = errors.New("size limit exceeded")
 	ErrEncodingFailed    = errors.New("encoding failed")
+	ErrWriteFailed       = errors.New("write failed")
 	ErrTraceFailed       = errors.New("trace error")
 	ErrInterceptorFailed = errors.New("interceptor error")
+	ErrHandlerPanicked   = errors.New("handler panicked")
 )
 
 type ConfigError struct {
@@ -23,6 +25,10 @@ func (e *ConfigError) Error() string {
 	return fmt.Sprintf("invalid configuration: %s - %s", e.Field, e.Msg)
 }
 
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrConfigInvalid
+}
+
 type ValidationError struct {
 	Field   string
 	Message string
@@ -42,6 +48,10 @@ func (e *SizeLimitError) Error() string {
 	return fmt.Sprintf("response size (%d bytes) exceeds limit (%d bytes)", e.Size, e.Max)
 }
 
+func (e *SizeLimitError) Is(target error) bool {
+	return target == ErrSizeLimitExceeded
+}
+
 type EncodingError struct {
 	Inner error
 }
@@ -50,6 +60,32 @@ func (e *EncodingError) Error() string {
 	return fmt.Sprintf("encoding failed: %v", e.Inner)
 }
 
+func (e *EncodingError) Is(target error) bool {
+	return target == ErrEncodingFailed
+}
+
+// Unwrap exposes the underlying encoder error
+func (e *EncodingError) Unwrap() error {
+	return e.Inner
+}
+
+type WriteError struct {
+	Inner error
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("write failed: %v", e.Inner)
+}
+
+func (e *WriteError) Is(target error) bool {
+	return target == ErrWriteFailed
+}
+
+// Unwrap exposes the underlying writer error (e.g. a broken pipe)
+func (e *WriteError) Unwrap() error {
+	return e.Inner
+}
+
 type TraceError struct {
 	Msg string
 }
@@ -67,6 +103,52 @@ func (e *InterceptorLimitError) Error() string {
 	return fmt.Sprintf("maximum number of interceptors reached: %d/%d", e.Current, e.Max)
 }
 
+type InterceptorNameError struct {
+	Name string
+	Msg  string
+}
+
+func (e *InterceptorNameError) Error() string {
+	return fmt.Sprintf("interceptor %q: %s", e.Name, e.Msg)
+}
+
+// InterceptorError reports an interceptor or BeforeSend stage that panicked,
+// or an async interceptor call dropped because its queue was full
+type InterceptorError struct {
+	Name  string // registration name, empty for anonymous interceptors
+	Panic any    // recovered value, nil when the interceptor did not panic
+	Msg   string
+}
+
+func (e *InterceptorError) Error() string {
+	name := e.Name
+	if name == "" {
+		name = "anonymous"
+	}
+	if e.Panic != nil {
+		return fmt.Sprintf("interceptor %s panicked: %v", name, e.Panic)
+	}
+	return fmt.Sprintf("interceptor %s: %s", name, e.Msg)
+}
+
+func (e *InterceptorError) Is(target error) bool {
+	return target == ErrInterceptorFailed
+}
+
+// PanicError reports a handler panic that Recoverer could not answer because
+// the handler had already started writing its response
+type PanicError struct {
+	Value any // recovered value
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("handler panicked after writing its response: %v", e.Value)
+}
+
+func (e *PanicError) Is(target error) bool {
+	return target == ErrHandlerPanicked
+}
+
 type StatusCodeError struct {
 	Code int
 }
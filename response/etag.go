@@ -0,0 +1,111 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WithETag sets the ETag header, the value is quoted if needed
+func (r *Response) WithETag(etag string) *Response {
+	return r.WithHeader("ETag", quoteETag(etag))
+}
+
+// ComputeETag derives an ETag from the encoded Data using the configured hash
+// (SHA-256 by default), weak when WeakETags is enabled
+func (r *Response) ComputeETag() (string, error) {
+	data, err := json.Marshal(r.Data)
+	if err != nil {
+		return "", &EncodingError{Inner: err}
+	}
+
+	config := r.getResponseConfig()
+	newHash := config.ETagHash
+	if newHash == nil {
+		newHash = sha256.New
+	}
+	h := newHash()
+	h.Write(data)
+
+	etag := `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+	if config.WeakETags {
+		etag = "W/" + etag
+	}
+	return etag, nil
+}
+
+// SendConditional sends the response unless the request If-None-Match matches
+// its ETag, in which case a body-less 304 Not Modified is sent instead
+// The ETag set with WithETag is used, otherwise one is computed from Data.
+// Responses with a non-2xx status are sent as they are (RFC 9110 §13.2.1)
+func (r *Response) SendConditional(req *http.Request, w http.ResponseWriter) error {
+	if r.Code < 200 || r.Code >= 300 {
+		return r.SendWithRequest(req, w)
+	}
+
+	etag := r.Headers.Get("ETag")
+	if etag == "" {
+		computed, err := r.ComputeETag()
+		if err != nil {
+			return r.SendWithRequest(req, w)
+		}
+		etag = computed
+		r.WithHeader("ETag", etag)
+	}
+
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		if etagMatches(req.Header.Values("If-None-Match"), etag) {
+			r.Code = http.StatusNotModified
+		}
+	}
+
+	return r.SendWithRequest(req, w)
+}
+
+// validatorHeaders are the headers a 304 must repeat (RFC 9110 §15.4.5)
+var validatorHeaders = []string{"Cache-Control", "Content-Location", "Date", "ETag", "Expires", "Last-Modified", "Vary"}
+
+// WithLastModified sets the Last-Modified header
+func (r *Response) WithLastModified(t time.Time) *Response {
+	return r.WithHeader("Last-Modified", t.UTC().Format(http.TimeFormat))
+}
+
+// WithValidatorsFrom copies the cache validators and related headers a 304
+// Not Modified must carry (ETag, Last-Modified, Cache-Control, Content-Location,
+// Date, Expires, Vary) from the headers of the full response
+func (r *Response) WithValidatorsFrom(h http.Header) *Response {
+	for _, key := range validatorHeaders {
+		if values := h.Values(key); len(values) > 0 {
+			if r.Headers == nil {
+				r.Headers = make(http.Header)
+			}
+			r.Headers[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+	}
+	return r
+}
+
+// etagMatches applies the weak comparison used by If-None-Match
+func etagMatches(headers []string, etag string) bool {
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, header := range headers {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == opaque {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quoteETag wraps an entity tag in quotes unless it already is one
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
@@ -0,0 +1,44 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendConditional(t *testing.T) {
+	tests := []struct {
+		name        string
+		resp        func() *Response
+		method      string
+		ifNoneMatch string
+		want        int
+	}{
+		{"matching etag", func() *Response { return OK().WithETag("v1") }, http.MethodGet, `"v1"`, http.StatusNotModified},
+		{"weak comparison", func() *Response { return OK().WithETag("v1") }, http.MethodGet, `W/"v1"`, http.StatusNotModified},
+		{"different etag", func() *Response { return OK().WithETag("v1") }, http.MethodGet, `"v2"`, http.StatusOK},
+		{"wildcard", func() *Response { return OK().WithData("x") }, http.MethodGet, "*", http.StatusNotModified},
+		{"unsafe method", func() *Response { return OK().WithETag("v1") }, http.MethodPost, `"v1"`, http.StatusOK},
+		{"client error", func() *Response { return NotFound().WithETag("v1") }, http.MethodGet, `"v1"`, http.StatusNotFound},
+		{"server error with wildcard", func() *Response { return InternalServerError() }, http.MethodGet, "*", http.StatusInternalServerError},
+		{"redirect", func() *Response { return Found("/elsewhere") }, http.MethodGet, "*", http.StatusFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			w := httptest.NewRecorder()
+
+			if err := tt.resp().SendConditional(req, w); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if w.Code == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Fatalf("304 carried a body: %s", w.Body)
+			}
+		})
+	}
+}
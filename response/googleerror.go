@@ -0,0 +1,183 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const googleTypePrefix = "type.googleapis.com/google.rpc."
+
+// googleStatuses maps HTTP status codes to google.rpc.Code names, following
+// the HTTP mapping documented in google/rpc/code.proto
+var googleStatuses = map[int]string{
+	http.StatusOK:                           "OK",
+	http.StatusBadRequest:                   "INVALID_ARGUMENT",
+	http.StatusUnauthorized:                 "UNAUTHENTICATED",
+	http.StatusForbidden:                    "PERMISSION_DENIED",
+	http.StatusNotFound:                     "NOT_FOUND",
+	http.StatusConflict:                     "ABORTED",
+	http.StatusPreconditionFailed:           "FAILED_PRECONDITION",
+	http.StatusRequestedRangeNotSatisfiable: "OUT_OF_RANGE",
+	http.StatusTooManyRequests:              "RESOURCE_EXHAUSTED",
+	499:                                     "CANCELLED",
+	http.StatusInternalServerError:          "INTERNAL",
+	http.StatusNotImplemented:               "UNIMPLEMENTED",
+	http.StatusServiceUnavailable:           "UNAVAILABLE",
+	http.StatusGatewayTimeout:               "DEADLINE_EXCEEDED",
+}
+
+// GoogleStatus returns the google.rpc.Code name for an HTTP status code, such
+// as "INVALID_ARGUMENT" for 400. Unmapped 2xx codes are "OK", unmapped 5xx
+// codes "INTERNAL" and anything else "UNKNOWN"
+func GoogleStatus(code int) string {
+	if status, ok := googleStatuses[code]; ok {
+		return status
+	}
+	switch {
+	case code >= 200 && code < 300:
+		return "OK"
+	case code >= 500:
+		return "INTERNAL"
+	}
+	return "UNKNOWN"
+}
+
+type googleErrorBody struct {
+	Error googleError `json:"error"`
+}
+
+type googleError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+	Details []any  `json:"details,omitempty"`
+}
+
+type googleErrorInfo struct {
+	Type     string            `json:"@type"`
+	Reason   string            `json:"reason"`
+	Domain   string            `json:"domain,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type googleBadRequest struct {
+	Type            string                 `json:"@type"`
+	FieldViolations []googleFieldViolation `json:"fieldViolations"`
+}
+
+type googleFieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+type googleHelp struct {
+	Type  string       `json:"@type"`
+	Links []googleLink `json:"links"`
+}
+
+type googleLink struct {
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+type googleRequestInfo struct {
+	Type      string `json:"@type"`
+	RequestID string `json:"requestId"`
+}
+
+type googleDebugInfo struct {
+	Type         string   `json:"@type"`
+	StackEntries []string `json:"stackEntries,omitempty"`
+	Detail       string   `json:"detail,omitempty"`
+}
+
+// AsGoogleError renders the response in Google's API error model (AIP-193):
+// an "error" object with the status code, message, canonical status name and
+// typed details. The error code becomes an ErrorInfo reason in the module's
+// domain, field errors and validation traces become BadRequest field
+// violations, and the docs URL, request ID and traces are reported as Help,
+// RequestInfo and DebugInfo
+func (r *Response) AsGoogleError() *Response {
+	r.format = googleErrorEncoder
+	return r
+}
+
+var googleErrorEncoder = EncoderFunc(func(w io.Writer, r *Response) error {
+	message := r.Message
+	if message == "" {
+		message = http.StatusText(r.Code)
+	}
+
+	body := googleErrorBody{Error: googleError{
+		Code:    r.Code,
+		Message: message,
+		Status:  GoogleStatus(r.Code),
+		Details: googleErrorDetails(r),
+	}}
+	return json.NewEncoder(w).Encode(body)
+})
+
+func googleErrorDetails(r *Response) []any {
+	var details []any
+
+	if r.ErrorCode != "" {
+		info := googleErrorInfo{
+			Type:   googleTypePrefix + "ErrorInfo",
+			Reason: r.ErrorCode,
+			Domain: r.Module,
+		}
+		if len(r.Meta) > 0 {
+			info.Metadata = make(map[string]string, len(r.Meta))
+			for k, v := range r.Meta {
+				info.Metadata[k] = fmt.Sprint(v)
+			}
+		}
+		details = append(details, info)
+	}
+
+	var violations []googleFieldViolation
+	for _, detail := range r.Errors {
+		if detail.Field == "" {
+			continue
+		}
+		violations = append(violations, googleFieldViolation{
+			Field:       detail.Field,
+			Description: detail.Message,
+			Reason:      detail.Code,
+		})
+	}
+	if len(violations) > 0 {
+		details = append(details, googleBadRequest{
+			Type:            googleTypePrefix + "BadRequest",
+			FieldViolations: violations,
+		})
+	}
+
+	if r.DocsURL != "" {
+		details = append(details, googleHelp{
+			Type:  googleTypePrefix + "Help",
+			Links: []googleLink{{Description: "Error documentation", URL: r.DocsURL}},
+		})
+	}
+
+	if r.RequestID != "" {
+		details = append(details, googleRequestInfo{
+			Type:      googleTypePrefix + "RequestInfo",
+			RequestID: r.RequestID,
+		})
+	}
+
+	if !r.getResponseConfig().OmitTrace && (len(r.Trace) > 0 || len(r.Stack) > 0) {
+		details = append(details, googleDebugInfo{
+			Type:         googleTypePrefix + "DebugInfo",
+			StackEntries: r.Stack,
+			Detail:       strings.Join(r.Trace, "\n"),
+		})
+	}
+
+	return details
+}
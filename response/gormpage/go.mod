@@ -0,0 +1,16 @@
+module github.com/MintzyG/FastUtilitiesNet/response/gormpage
+
+go 1.25.3
+
+replace github.com/MintzyG/FastUtilitiesNet => ../..
+
+require (
+	github.com/MintzyG/FastUtilitiesNet v0.0.0-00010101000000-000000000000
+	gorm.io/gorm v1.31.2
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
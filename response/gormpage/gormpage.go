@@ -0,0 +1,55 @@
+// Package gormpage applies response pagination to GORM queries.
+package gormpage
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// Scope limits a query to the page described by params
+//
+//	db.Scopes(gormpage.Scope(params)).Find(&users)
+func Scope(params response.PaginationParams) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Limit(params.Limit).Offset(params.Offset())
+	}
+}
+
+// Find counts the rows matched by db and loads the requested page of them,
+// returning the page together with its pagination meta
+func Find[T any](db *gorm.DB, params response.PaginationParams) ([]T, response.PaginationMeta, error) {
+	total, err := count[T](db)
+	if err != nil {
+		return nil, response.PaginationMeta{}, err
+	}
+
+	items, err := page[T](db, params.Limit, params.Offset())
+	if err != nil {
+		return nil, response.PaginationMeta{}, err
+	}
+
+	return items, response.CreatePaginationMeta(params, total), nil
+}
+
+// Respond fills r with the page of rows matched by db and its pagination meta.
+// A failing query replaces r with the response FromError builds for it
+func Respond[T any](r *response.Response, db *gorm.DB, params response.PaginationParams) *response.Response {
+	return r.WithPaginatedQuery(params,
+		func() (int64, error) { return count[T](db) },
+		func(limit, offset int) (any, error) { return page[T](db, limit, offset) },
+	)
+}
+
+// count runs the count query on a fresh session so db stays reusable
+func count[T any](db *gorm.DB) (int64, error) {
+	var total int64
+	err := db.Session(&gorm.Session{}).Model(new(T)).Count(&total).Error
+	return total, err
+}
+
+func page[T any](db *gorm.DB, limit, offset int) ([]T, error) {
+	items := []T{}
+	err := db.Session(&gorm.Session{}).Limit(limit).Offset(offset).Find(&items).Error
+	return items, err
+}
@@ -0,0 +1,40 @@
+package gormpage
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+	gormtests "gorm.io/gorm/utils/tests"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+type order struct {
+	ID uint
+}
+
+func TestScope(t *testing.T) {
+	db, err := gorm.Open(gormtests.DummyDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		params response.PaginationParams
+		want   string
+	}{
+		{"first page", response.PaginationParams{Page: 1, Limit: 10}, "SELECT * FROM `orders` LIMIT 10"},
+		{"third page", response.PaginationParams{Page: 3, Limit: 25}, "SELECT * FROM `orders` LIMIT 25 OFFSET 50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var orders []order
+			stmt := db.Scopes(Scope(tt.params)).Find(&orders).Statement
+			if got := db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...); got != tt.want {
+				t.Fatalf("SQL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
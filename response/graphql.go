@@ -0,0 +1,117 @@
+package response
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// graphQLCodes maps HTTP status codes to the extension codes GraphQL servers
+// conventionally use, for responses without an error code of their own
+var graphQLCodes = map[int]string{
+	http.StatusBadRequest:          "BAD_USER_INPUT",
+	http.StatusUnauthorized:        "UNAUTHENTICATED",
+	http.StatusForbidden:           "FORBIDDEN",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusConflict:            "CONFLICT",
+	http.StatusTooManyRequests:     "RATE_LIMITED",
+	http.StatusInternalServerError: "INTERNAL_SERVER_ERROR",
+}
+
+func graphQLCode(code int) string {
+	if c, ok := graphQLCodes[code]; ok {
+		return c
+	}
+	if code >= 500 {
+		return "INTERNAL_SERVER_ERROR"
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(code), " ", "_"))
+}
+
+type graphQLBody struct {
+	Data   any            `json:"data,omitempty"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+type graphQLError struct {
+	Message    string            `json:"message"`
+	Path       []any             `json:"path,omitempty"`
+	Extensions graphQLExtensions `json:"extensions"`
+}
+
+type graphQLExtensions struct {
+	Code  string `json:"code"`
+	Field string `json:"field,omitempty"`
+}
+
+// AsGraphQLErrors renders the response in the GraphQL response shape so
+// gateways can pass REST failures on to GraphQL clients. Field errors and
+// validation traces become one error each, with the field as its path;
+// otherwise the message is reported as a single error. extensions.code is
+// the error code, or one derived from the status code
+func (r *Response) AsGraphQLErrors() *Response {
+	r.format = graphQLEncoder
+	return r
+}
+
+var graphQLEncoder = EncoderFunc(func(w io.Writer, r *Response) error {
+	body := graphQLBody{Data: r.RedactedData()}
+	if r.Code >= 400 {
+		body.Errors = graphQLErrors(r)
+	}
+	return json.NewEncoder(w).Encode(body)
+})
+
+func graphQLErrors(r *Response) []graphQLError {
+	defaultCode := r.ErrorCode
+	if defaultCode == "" {
+		defaultCode = graphQLCode(r.Code)
+	}
+
+	details := r.Errors
+	if len(details) == 0 {
+		message := r.Message
+		if message == "" {
+			message = http.StatusText(r.Code)
+		}
+		return []graphQLError{{Message: message, Extensions: graphQLExtensions{Code: defaultCode}}}
+	}
+
+	errs := make([]graphQLError, len(details))
+	for i, detail := range details {
+		code := detail.Code
+		if code == "" {
+			code = defaultCode
+		}
+		message := detail.Message
+		if detail.Field != "" {
+			message = detail.Field + " " + message
+		}
+		errs[i] = graphQLError{
+			Message:    message,
+			Path:       graphQLPath(detail.Field),
+			Extensions: graphQLExtensions{Code: code, Field: detail.Field},
+		}
+	}
+	return errs
+}
+
+// graphQLPath splits a dotted field name into path segments, with numeric
+// segments as list indices
+func graphQLPath(field string) []any {
+	if field == "" {
+		return nil
+	}
+	segments := strings.Split(field, ".")
+	path := make([]any, len(segments))
+	for i, segment := range segments {
+		if n, err := strconv.Atoi(segment); err == nil {
+			path[i] = n
+		} else {
+			path[i] = segment
+		}
+	}
+	return path
+}
@@ -0,0 +1,18 @@
+module github.com/MintzyG/FastUtilitiesNet/response/grpcbridge
+
+go 1.25.3
+
+replace github.com/MintzyG/FastUtilitiesNet => ../..
+
+require (
+	github.com/MintzyG/FastUtilitiesNet v0.0.0-00010101000000-000000000000
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260414002931-afd174a4e478
+	google.golang.org/grpc v1.82.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
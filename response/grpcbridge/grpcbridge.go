@@ -0,0 +1,136 @@
+// Package grpcbridge maps responses to gRPC statuses so services serving both
+// REST and gRPC share one error model.
+package grpcbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Code returns the gRPC code for an HTTP status code, following the same
+// mapping as response.GoogleStatus
+func Code(httpStatus int) codes.Code {
+	var c codes.Code
+	if err := c.UnmarshalJSON([]byte(`"` + response.GoogleStatus(httpStatus) + `"`)); err != nil {
+		return codes.Unknown
+	}
+	return c
+}
+
+// Status converts a response into a gRPC status as clients would see it over
+// HTTP, after environment stripping and redaction. The error code becomes an
+// ErrorInfo reason in the module's domain, field errors become BadRequest
+// field violations, and the docs URL, request ID and traces are attached as
+// Help, RequestInfo and DebugInfo details. Traces are left out with OmitTrace
+func Status(resp *response.Response) *status.Status {
+	r := resp.Exposed()
+
+	message := r.Message
+	if message == "" {
+		message = http.StatusText(r.Code)
+	}
+	st := status.New(Code(r.Code), message)
+
+	details := Details(r)
+	if len(details) == 0 {
+		return st
+	}
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// Details builds the google.rpc error details describing a response
+func Details(r *response.Response) []protoadapt.MessageV1 {
+	var details []protoadapt.MessageV1
+
+	if r.ErrorCode != "" {
+		info := &errdetails.ErrorInfo{Reason: r.ErrorCode, Domain: r.Module}
+		if len(r.Meta) > 0 {
+			info.Metadata = make(map[string]string, len(r.Meta))
+			for k, v := range r.Meta {
+				info.Metadata[k] = fmt.Sprint(v)
+			}
+		}
+		details = append(details, info)
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range r.Errors {
+		if detail.Field == "" {
+			continue
+		}
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       detail.Field,
+			Description: detail.Message,
+			Reason:      detail.Code,
+		})
+	}
+	if len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	if r.DocsURL != "" {
+		details = append(details, &errdetails.Help{
+			Links: []*errdetails.Help_Link{{Description: "Error documentation", Url: r.DocsURL}},
+		})
+	}
+
+	if r.RequestID != "" {
+		details = append(details, &errdetails.RequestInfo{RequestId: r.RequestID})
+	}
+
+	if !r.Config().OmitTrace && (len(r.Trace) > 0 || len(r.Stack) > 0) {
+		details = append(details, &errdetails.DebugInfo{
+			StackEntries: r.Stack,
+			Detail:       strings.Join(r.Trace, "\n"),
+		})
+	}
+
+	return details
+}
+
+// Error converts an error carrying a *response.Response into a gRPC status
+// error. Other errors, including existing gRPC statuses, are returned as is.
+// A response with a non-error code still fails the call, with code Unknown
+func Error(err error) error {
+	var resp *response.Response
+	if !errors.As(err, &resp) || resp == nil {
+		return err
+	}
+
+	st := Status(resp)
+	if st.Code() == codes.OK {
+		st = status.New(codes.Unknown, st.Message())
+	}
+	return st.Err()
+}
+
+// UnaryServerInterceptor converts *response.Response errors returned by unary
+// handlers into gRPC statuses
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, Error(err)
+	}
+}
+
+// StreamServerInterceptor converts *response.Response errors returned by
+// streaming handlers into gRPC statuses
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return Error(handler(srv, ss))
+	}
+}
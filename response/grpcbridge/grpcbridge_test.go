@@ -0,0 +1,83 @@
+package grpcbridge
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		status int
+		want   codes.Code
+	}{
+		{http.StatusOK, codes.OK},
+		{http.StatusCreated, codes.OK},
+		{http.StatusBadRequest, codes.InvalidArgument},
+		{http.StatusNotFound, codes.NotFound},
+		{499, codes.Canceled},
+		{http.StatusTeapot, codes.Unknown},
+		{http.StatusBadGateway, codes.Internal},
+		{http.StatusGatewayTimeout, codes.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			if got := Code(tt.status); got != tt.want {
+				t.Fatalf("Code(%d) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name      string
+		resp      *response.Response
+		want      codes.Code
+		debugInfo bool
+	}{
+		{"not found", response.NotFound("no such order"), codes.NotFound, false},
+		{"with trace", response.BadRequest("invalid").AddTrace("parsing body"), codes.InvalidArgument, true},
+		{"omit trace", response.BadRequest("invalid").WithConfig(response.Config{OmitTrace: true}).AddTrace("parsing body"), codes.InvalidArgument, false},
+		{"success", response.OK("done"), codes.Unknown, false},
+		{"pooled", response.Acquire("conflict").WithCode(http.StatusConflict), codes.Aborted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Error(fmt.Errorf("handler: %w", tt.resp))
+			st, ok := status.FromError(err)
+			if !ok {
+				t.Fatalf("Error returned %T, want a gRPC status", err)
+			}
+			if st.Code() != tt.want {
+				t.Fatalf("code = %v, want %v", st.Code(), tt.want)
+			}
+
+			var debugInfo bool
+			for _, detail := range st.Details() {
+				if _, ok := detail.(*errdetails.DebugInfo); ok {
+					debugInfo = true
+				}
+			}
+			if debugInfo != tt.debugInfo {
+				t.Fatalf("DebugInfo attached = %v, want %v", debugInfo, tt.debugInfo)
+			}
+			if tt.resp.Code == 0 {
+				t.Fatal("Error released the response")
+			}
+		})
+	}
+
+	plain := errors.New("boom")
+	if err := Error(plain); err != plain {
+		t.Fatalf("Error(%v) = %v, want it unchanged", plain, err)
+	}
+}
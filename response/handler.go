@@ -0,0 +1,83 @@
+package response
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// HandlerFunc is a handler that returns its response instead of writing it
+type HandlerFunc func(r *http.Request) (*Response, error)
+
+// Handler adapts a HandlerFunc into an http.HandlerFunc
+// The returned response is sent with the request context, errors are mapped
+// with FromError and a nil response becomes NoContent
+func Handler(fn HandlerFunc) http.HandlerFunc {
+	return defaultResponder.Handler(fn)
+}
+
+// Handler adapts a HandlerFunc into an http.HandlerFunc using this Responder
+// to build the responses for errors and empty results. Errors sending the
+// response go to Config.OnInterceptorError
+func (rs *Responder) Handler(fn HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		resp, err := fn(req)
+		if err != nil {
+			resp = rs.FromError(err)
+		} else if resp == nil {
+			resp = rs.NoContent()
+		}
+		if err := resp.SendWithRequest(req, w); err != nil {
+			rs.reportInterceptorError(err)
+		}
+	}
+}
+
+// Methods dispatches requests by method using the default Responder
+func Methods(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	return defaultResponder.Methods(handlers)
+}
+
+// Methods dispatches requests to the handler registered for their method
+// HEAD falls back to GET and OPTIONS is answered with the Allow header when
+// not registered, other methods get a 405 envelope with the Allow header
+func (rs *Responder) Methods(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	byMethod := make(map[string]http.HandlerFunc, len(handlers))
+	for method, h := range handlers {
+		byMethod[strings.ToUpper(method)] = h
+	}
+	if _, ok := byMethod[http.MethodHead]; !ok {
+		if get, ok := byMethod[http.MethodGet]; ok {
+			byMethod[http.MethodHead] = get
+		}
+	}
+
+	allowed := make([]string, 0, len(byMethod)+1)
+	for method := range byMethod {
+		allowed = append(allowed, method)
+	}
+	if _, ok := byMethod[http.MethodOptions]; !ok {
+		allowed = append(allowed, http.MethodOptions)
+	}
+	sort.Strings(allowed)
+	allow := strings.Join(allowed, ", ")
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		if h, ok := byMethod[req.Method]; ok {
+			h(w, req)
+			return
+		}
+
+		var resp *Response
+		if req.Method == http.MethodOptions {
+			resp = rs.NoContent().WithHeader("Allow", allow)
+		} else {
+			resp = rs.MethodNotAllowed("Method not allowed").
+				WithHeader("Allow", allow).
+				appendTraceInternal("method", req.Method+" is not one of "+allow)
+		}
+		if err := resp.SendWithRequest(req, w); err != nil {
+			rs.reportInterceptorError(err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// failingWriter accepts headers but fails every body write
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestHandlerReportsSendErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(rs *Responder) http.HandlerFunc
+		failing bool
+	}{
+		{"handler ok", func(rs *Responder) http.HandlerFunc {
+			return rs.Handler(func(*http.Request) (*Response, error) { return rs.OK("done"), nil })
+		}, false},
+		{"handler write failure", func(rs *Responder) http.HandlerFunc {
+			return rs.Handler(func(*http.Request) (*Response, error) { return rs.OK("done"), nil })
+		}, true},
+		{"method not allowed write failure", func(rs *Responder) http.HandlerFunc {
+			return rs.Methods(map[string]http.HandlerFunc{http.MethodPost: func(http.ResponseWriter, *http.Request) {}})
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []error
+			rs := NewResponder(Config{OnInterceptorError: func(err error) { reported = append(reported, err) }})
+
+			var w http.ResponseWriter = httptest.NewRecorder()
+			if tt.failing {
+				w = failingWriter{httptest.NewRecorder()}
+			}
+			tt.handler(rs)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if !tt.failing {
+				if len(reported) != 0 {
+					t.Fatalf("reported %v, want nothing", reported)
+				}
+				return
+			}
+			var writeErr *WriteError
+			if len(reported) != 1 || !errors.As(reported[0], &writeErr) {
+				t.Fatalf("reported %v, want one write error", reported)
+			}
+		})
+	}
+}
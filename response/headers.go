@@ -0,0 +1,90 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+)
+
+// WithHeader sets a header sent along with the response
+func (r *Response) WithHeader(key, value string) *Response {
+	if r.Headers == nil {
+		r.Headers = make(http.Header)
+	}
+	r.Headers.Set(key, value)
+	return r
+}
+
+// AddHeader adds a value to a header sent along with the response
+func (r *Response) AddHeader(key, value string) *Response {
+	if r.Headers == nil {
+		r.Headers = make(http.Header)
+	}
+	r.Headers.Add(key, value)
+	return r
+}
+
+// WithCookie sets a cookie sent along with the response
+// CookieSameSite and CookieSecure from the config apply to cookies without them
+func (r *Response) WithCookie(cookie *http.Cookie) *Response {
+	if cookie != nil {
+		r.cookies = append(r.cookies, cookie)
+	}
+	return r
+}
+
+// applyHeaders copies the response headers and cookies to w
+func (r *Response) applyHeaders(w http.ResponseWriter) {
+	header := w.Header()
+	for key, values := range r.Headers {
+		header[key] = append([]string(nil), values...)
+	}
+
+	if len(r.cookies) == 0 {
+		return
+	}
+
+	config := r.getResponseConfig()
+	for _, cookie := range r.cookies {
+		c := *cookie
+		if c.SameSite == 0 {
+			c.SameSite = config.CookieSameSite
+		}
+		if config.CookieSecure {
+			c.Secure = true
+		}
+		http.SetCookie(w, &c)
+	}
+}
+
+// WithLocation sets the Location header
+func (r *Response) WithLocation(url string) *Response {
+	return r.WithHeader("Location", url)
+}
+
+// SendEarlyHints writes a 103 Early Hints informational response carrying
+// Link headers so clients can start preloading before the final response is
+// sent with the normal Send flow. Plain URLs become rel=preload links, values
+// starting with "<" are used as complete Link values
+func SendEarlyHints(w http.ResponseWriter, links []string) {
+	if len(links) == 0 {
+		return
+	}
+
+	header := w.Header()
+	for _, link := range links {
+		if strings.HasPrefix(link, "<") {
+			header.Add("Link", link)
+		} else {
+			header.Add("Link", "<"+link+">; rel=preload")
+		}
+	}
+	w.WriteHeader(http.StatusEarlyHints)
+}
+
+// withSupportedMediaTypes lists the decodable media types in the Accept-Post
+// and Accept-Patch headers and the envelope, for 415 responses
+func (r *Response) withSupportedMediaTypes() *Response {
+	r.SupportedTypes = SupportedMediaTypes()
+	accepted := strings.Join(r.SupportedTypes, ", ")
+	return r.WithHeader("Accept-Post", accepted).WithHeader("Accept-Patch", accepted)
+}
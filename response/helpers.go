@@ -41,14 +41,7 @@ func (r *Response) validateResponseSize() error {
 		return &EncodingError{Inner: err}
 	}
 
-	if size > config.ResponseSizeLimit {
-		return &SizeLimitError{
-			Size: size,
-			Max:  config.ResponseSizeLimit,
-		}
-	}
-
-	return nil
+	return r.checkSize(size)
 }
 
 func (r *Response) GetResponseStats() map[string]any {
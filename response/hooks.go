@@ -0,0 +1,78 @@
+package response
+
+import "slices"
+
+// Hooks are callbacks at fixed points of the response lifecycle. Any of them
+// may be nil
+type Hooks struct {
+	// OnBuild runs when a builder of the Responder creates a response.
+	// It is ignored for hooks attached with WithHooks
+	OnBuild func(resp *Response)
+	// OnBeforeEncode runs after the interceptors, right before the response
+	// is rendered for sending
+	OnBeforeEncode func(resp *Response)
+	// OnAfterWrite runs once the response was written, with the number of
+	// body bytes written and the write error if any
+	OnAfterWrite func(resp *Response, bytesWritten int, err error)
+}
+
+// AddHooks registers lifecycle hooks for every response of this Responder
+func (rs *Responder) AddHooks(h Hooks) {
+	rs.hooksMu.Lock()
+	defer rs.hooksMu.Unlock()
+	rs.hooks = append(slices.Clip(rs.hooks), h)
+}
+
+// AddHooks registers lifecycle hooks on the default Responder
+func AddHooks(h Hooks) {
+	defaultResponder.AddHooks(h)
+}
+
+// WithHooks attaches lifecycle hooks to this response only, they run after
+// the hooks of the Responder
+func (r *Response) WithHooks(h Hooks) *Response {
+	r.hooks = append(r.hooks, h)
+	return r
+}
+
+func (rs *Responder) getHooks() []Hooks {
+	rs.hooksMu.RLock()
+	defer rs.hooksMu.RUnlock()
+	return rs.hooks
+}
+
+// built runs the OnBuild hooks of the Responder for a new response
+func (rs *Responder) built(r *Response) *Response {
+	for _, h := range rs.getHooks() {
+		if h.OnBuild != nil {
+			h.OnBuild(r)
+		}
+	}
+	return r
+}
+
+// eachHook calls fn with the Responder hooks followed by the response hooks
+func (r *Response) eachHook(fn func(h Hooks)) {
+	for _, h := range r.responder().getHooks() {
+		fn(h)
+	}
+	for _, h := range r.hooks {
+		fn(h)
+	}
+}
+
+func (r *Response) runBeforeEncode() {
+	r.eachHook(func(h Hooks) {
+		if h.OnBeforeEncode != nil {
+			h.OnBeforeEncode(r)
+		}
+	})
+}
+
+func (r *Response) runAfterWrite(bytesWritten int, err error) {
+	r.eachHook(func(h Hooks) {
+		if h.OnAfterWrite != nil {
+			h.OnAfterWrite(r, bytesWritten, err)
+		}
+	})
+}
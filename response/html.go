@@ -0,0 +1,61 @@
+package response
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sync"
+)
+
+const mediaTypeHTML = "text/html"
+
+// HTMLRenderer is an Encoder rendering responses with html/template, choosing
+// the template by status class (2xx, 4xx, 5xx...). The template receives the
+// *Response as its data. Register it for text/html so browsers sending
+// Accept: text/html get a page through SendNegotiated
+type HTMLRenderer struct {
+	fallback *template.Template
+	classes  map[int]*template.Template
+	mu       sync.RWMutex
+}
+
+// NewHTMLRenderer creates a renderer using fallback for status classes
+// without a dedicated template, fallback may be nil
+func NewHTMLRenderer(fallback *template.Template) *HTMLRenderer {
+	return &HTMLRenderer{
+		fallback: fallback,
+		classes:  make(map[int]*template.Template),
+	}
+}
+
+// ForClass sets the template used for a status class, e.g. 4 for 4xx
+func (h *HTMLRenderer) ForClass(class int, tmpl *template.Template) *HTMLRenderer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.classes[class] = tmpl
+	return h
+}
+
+func (h *HTMLRenderer) Encode(w io.Writer, r *Response) error {
+	h.mu.RLock()
+	tmpl, ok := h.classes[r.Code/100]
+	h.mu.RUnlock()
+
+	if !ok {
+		tmpl = h.fallback
+	}
+	if tmpl == nil {
+		return fmt.Errorf("html: no template for status %d", r.Code)
+	}
+	return tmpl.Execute(w, r)
+}
+
+// RegisterHTMLRenderer registers the renderer for text/html on this Responder
+func (rs *Responder) RegisterHTMLRenderer(h *HTMLRenderer) {
+	rs.RegisterEncoder(mediaTypeHTML, h)
+}
+
+// RegisterHTMLRenderer registers the renderer for text/html on the default Responder
+func RegisterHTMLRenderer(h *HTMLRenderer) {
+	defaultResponder.RegisterHTMLRenderer(h)
+}
@@ -2,7 +2,10 @@ package response
 
 import (
 	"context"
-	"sync"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
 )
 
 type ResponseInterceptor interface {
@@ -13,40 +16,291 @@ type ResponseInterceptor interface {
 	InterceptSimple(response *Response, statusCode int)
 }
 
-// Thread-safe interceptors registry
-var (
-	interceptors   []ResponseInterceptor
-	interceptorsMu sync.RWMutex
-)
+// RequestAwareInterceptor is implemented by interceptors that want to see the
+// request being answered, to label logs or metrics by method and route.
+// Responses sent with SendWithRequest call InterceptRequest in place of
+// Intercept, the request context is available through req.Context()
+type RequestAwareInterceptor interface {
+	ResponseInterceptor
+	InterceptRequest(req *http.Request, response *Response, statusCode int)
+}
+
+// BeforeSendFunc runs before a response is sent, ahead of the interceptors.
+// It may modify resp in place, return a different response to send instead,
+// or return an error to replace it with the response FromError builds for the
+// error. Returning nil, nil keeps resp
+type BeforeSendFunc func(ctx context.Context, resp *Response) (*Response, error)
+
+// registeredInterceptor is an interceptor with its registration options
+type registeredInterceptor struct {
+	name        string // empty for anonymous interceptors
+	interceptor ResponseInterceptor
+	priority    int
+	conditions  []func(resp *Response, statusCode int) bool
+	async       bool
+	timeout     time.Duration
+}
+
+// accepts reports whether the interceptor should see the response
+func (reg registeredInterceptor) accepts(resp *Response, statusCode int) bool {
+	for _, cond := range reg.conditions {
+		if !cond(resp, statusCode) {
+			return false
+		}
+	}
+	return true
+}
+
+// InterceptorOption configures how an interceptor is registered
+type InterceptorOption func(*registeredInterceptor)
+
+// Priority orders the interceptor in the chain: lower priorities run first and
+// equal priorities run in registration order. The default priority is 0
+func Priority(p int) InterceptorOption {
+	return func(reg *registeredInterceptor) {
+		reg.priority = p
+	}
+}
+
+// OnlyStatus runs the interceptor only for status codes between min and max,
+// inclusive. OnlyStatus(500, 599) limits it to server errors
+func OnlyStatus(min, max int) InterceptorOption {
+	return OnlyWhen(func(_ *Response, statusCode int) bool {
+		return statusCode >= min && statusCode <= max
+	})
+}
+
+// OnlyModule runs the interceptor only for responses of the given modules
+func OnlyModule(modules ...string) InterceptorOption {
+	return OnlyWhen(func(resp *Response, _ int) bool {
+		return slices.Contains(modules, resp.Module)
+	})
+}
+
+// OnlyWhen runs the interceptor only for responses matching cond. Conditions
+// from several options must all match
+func OnlyWhen(cond func(resp *Response, statusCode int) bool) InterceptorOption {
+	return func(reg *registeredInterceptor) {
+		reg.conditions = append(reg.conditions, cond)
+	}
+}
+
+// Sample runs the interceptor for the given fraction of responses, between
+// 0 and 1, so expensive sinks stay affordable on busy endpoints
+func Sample(rate float64) InterceptorOption {
+	return OnlyWhen(func(_ *Response, _ int) bool {
+		return sampled(rate)
+	})
+}
+
+// SampleStatus samples only responses with status codes between min and max,
+// inclusive, and lets every other response through. Combine it to sample by
+// status class:
+//
+//	AddInterceptor(sink, SampleStatus(200, 299, 0.01)) // 1% of 2xx, all errors
+func SampleStatus(min, max int, rate float64) InterceptorOption {
+	return OnlyWhen(func(_ *Response, statusCode int) bool {
+		if statusCode < min || statusCode > max {
+			return true
+		}
+		return sampled(rate)
+	})
+}
+
+func sampled(rate float64) bool {
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
 
 // Interceptor should only be added during downtimes or application initializtion
-func AddInterceptor(interceptor ResponseInterceptor) error {
-	interceptorsMu.Lock()
-	defer interceptorsMu.Unlock()
+func (rs *Responder) AddInterceptor(interceptor ResponseInterceptor, opts ...InterceptorOption) error {
+	return rs.addInterceptor(newRegistration("", interceptor, opts))
+}
 
-	config := getConfig()
-	if len(interceptors) >= config.MaxInterceptorAmount {
+// AddNamedInterceptor registers an interceptor under a unique name so it can
+// later be removed or replaced on its own
+func (rs *Responder) AddNamedInterceptor(name string, interceptor ResponseInterceptor, opts ...InterceptorOption) error {
+	if name == "" {
+		return &InterceptorNameError{Name: name, Msg: "name must not be empty"}
+	}
+	return rs.addInterceptor(newRegistration(name, interceptor, opts))
+}
+
+func newRegistration(name string, interceptor ResponseInterceptor, opts []InterceptorOption) registeredInterceptor {
+	reg := registeredInterceptor{name: name, interceptor: interceptor}
+	for _, opt := range opts {
+		opt(&reg)
+	}
+	return reg
+}
+
+func (rs *Responder) addInterceptor(reg registeredInterceptor) error {
+	rs.interceptorsMu.Lock()
+	defer rs.interceptorsMu.Unlock()
+
+	config := rs.GetConfig()
+	if len(rs.interceptors) >= config.MaxInterceptorAmount {
 		return &InterceptorLimitError{
-			Current: len(interceptors),
+			Current: len(rs.interceptors),
 			Max:     config.MaxInterceptorAmount,
 		}
 	}
+	if reg.name != "" && rs.interceptorIndex(reg.name) >= 0 {
+		return &InterceptorNameError{Name: reg.name, Msg: "already registered"}
+	}
 
-	interceptors = append(interceptors, interceptor)
+	// Insert after every interceptor of lower or equal priority, copying so
+	// snapshots taken by GetInterceptors stay untouched
+	i := len(rs.interceptors)
+	for i > 0 && rs.interceptors[i-1].priority > reg.priority {
+		i--
+	}
+	rs.interceptors = slices.Insert(slices.Clip(rs.interceptors), i, reg)
 	return nil
 }
 
-func RemoveAllInterceptors() {
-	interceptorsMu.Lock()
-	defer interceptorsMu.Unlock()
-	interceptors = nil
+// RemoveInterceptor unregisters the interceptor with the given name and
+// reports whether it was registered
+func (rs *Responder) RemoveInterceptor(name string) bool {
+	rs.interceptorsMu.Lock()
+	defer rs.interceptorsMu.Unlock()
+
+	i := rs.interceptorIndex(name)
+	if i < 0 {
+		return false
+	}
+	// Copy on removal, snapshots taken by GetInterceptors stay untouched
+	rs.interceptors = append(rs.interceptors[:i:i], rs.interceptors[i+1:]...)
+	return true
 }
 
-func GetInterceptors() []ResponseInterceptor {
-	interceptorsMu.RLock()
-	defer interceptorsMu.RUnlock()
+// ReplaceInterceptor swaps the interceptor registered under name, keeping its
+// position and options in the chain
+func (rs *Responder) ReplaceInterceptor(name string, interceptor ResponseInterceptor) error {
+	rs.interceptorsMu.Lock()
+	defer rs.interceptorsMu.Unlock()
+
+	i := rs.interceptorIndex(name)
+	if i < 0 {
+		return &InterceptorNameError{Name: name, Msg: "not registered"}
+	}
+	rs.interceptors[i].interceptor = interceptor
+	return nil
+}
+
+// interceptorIndex returns the position of a named interceptor or -1
+// Callers must hold interceptorsMu
+func (rs *Responder) interceptorIndex(name string) int {
+	if name == "" {
+		return -1
+	}
+	for i, reg := range rs.interceptors {
+		if reg.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// RemoveAllInterceptors drops the interceptors and BeforeSend stages
+func (rs *Responder) RemoveAllInterceptors() {
+	rs.interceptorsMu.Lock()
+	defer rs.interceptorsMu.Unlock()
+	rs.interceptors = nil
+	rs.beforeSend = nil
+}
+
+// AddBeforeSend registers a BeforeSend stage. Stages run in registration
+// order, each receiving the response returned by the previous one
+func (rs *Responder) AddBeforeSend(fn BeforeSendFunc) {
+	rs.interceptorsMu.Lock()
+	defer rs.interceptorsMu.Unlock()
+	rs.beforeSend = append(slices.Clip(rs.beforeSend), fn)
+}
+
+// runBeforeSend passes the response through the BeforeSend stages and returns
+// the response to send
+func (r *Response) runBeforeSend(ctx context.Context) *Response {
+	rs := r.responder()
+	rs.interceptorsMu.RLock()
+	stages := rs.beforeSend
+	rs.interceptorsMu.RUnlock()
+
+	current := r
+	for _, stage := range stages {
+		next, err := rs.callBeforeSend(ctx, stage, current)
+		if err != nil {
+			next = rs.FromError(err)
+		}
+		if next != nil && next != current {
+			current.Release()
+			current = next
+		}
+	}
+	return current
+}
+
+// WithInterceptor attaches an interceptor to this response only, for events
+// that concern a single handler. It runs with the registered interceptors,
+// after those of equal priority, and accepts the same options
+func (r *Response) WithInterceptor(interceptor ResponseInterceptor, opts ...InterceptorOption) *Response {
+	r.interceptors = append(r.interceptors, newRegistration("", interceptor, opts))
+	return r
+}
+
+// callBeforeSend runs one stage, a panicking stage keeps the response as is
+func (rs *Responder) callBeforeSend(ctx context.Context, stage BeforeSendFunc, r *Response) (next *Response, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			rs.reportInterceptorError(&InterceptorError{Name: "before send", Panic: v})
+			next, err = nil, nil
+		}
+	}()
+	return stage(ctx, r)
+}
+
+// interceptorChain returns a copy of the registrations in execution order
+func (rs *Responder) interceptorChain() []registeredInterceptor {
+	rs.interceptorsMu.RLock()
+	defer rs.interceptorsMu.RUnlock()
+	return slices.Clone(rs.interceptors)
+}
+
+func (rs *Responder) GetInterceptors() []ResponseInterceptor {
+	rs.interceptorsMu.RLock()
+	defer rs.interceptorsMu.RUnlock()
 	// Return a copy to prevent external modification
-	result := make([]ResponseInterceptor, len(interceptors))
-	copy(result, interceptors)
+	result := make([]ResponseInterceptor, len(rs.interceptors))
+	for i, reg := range rs.interceptors {
+		result[i] = reg.interceptor
+	}
 	return result
 }
+
+// Package-level registry operating on the default Responder
+func AddInterceptor(interceptor ResponseInterceptor, opts ...InterceptorOption) error {
+	return defaultResponder.AddInterceptor(interceptor, opts...)
+}
+
+func AddNamedInterceptor(name string, interceptor ResponseInterceptor, opts ...InterceptorOption) error {
+	return defaultResponder.AddNamedInterceptor(name, interceptor, opts...)
+}
+
+func RemoveInterceptor(name string) bool {
+	return defaultResponder.RemoveInterceptor(name)
+}
+
+func ReplaceInterceptor(name string, interceptor ResponseInterceptor) error {
+	return defaultResponder.ReplaceInterceptor(name, interceptor)
+}
+
+func AddBeforeSend(fn BeforeSendFunc) {
+	defaultResponder.AddBeforeSend(fn)
+}
+
+func RemoveAllInterceptors() {
+	defaultResponder.RemoveAllInterceptors()
+}
+
+func GetInterceptors() []ResponseInterceptor {
+	return defaultResponder.GetInterceptors()
+}
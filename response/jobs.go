@@ -0,0 +1,68 @@
+package response
+
+import (
+	"strconv"
+	"time"
+)
+
+// JobState is the lifecycle state of an asynchronous job
+type JobState string
+
+const (
+	JobPending   JobState = "pending"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+)
+
+// JobStatus is the envelope Data describing an asynchronous job
+type JobStatus struct {
+	ID        string   `json:"id" xml:"id"`
+	State     JobState `json:"state" xml:"state"`
+	Progress  int      `json:"progress" xml:"progress"` // percent
+	ResultURL string   `json:"result_url,omitempty" xml:"result_url,omitempty"`
+	Error     string   `json:"error,omitempty" xml:"error,omitempty"`
+	// RetryAfter is sent as the Retry-After header while the job is not done
+	RetryAfter time.Duration `json:"-" xml:"-"`
+}
+
+// AcceptedJob answers 202 Accepted for a job created on the default Responder
+func AcceptedJob(jobID, statusURL string) *Response {
+	return defaultResponder.AcceptedJob(jobID, statusURL)
+}
+
+// PollJob builds the status resource response on the default Responder
+func PollJob(status JobStatus) *Response {
+	return defaultResponder.PollJob(status)
+}
+
+// AcceptedJob answers 202 Accepted with Location and Content-Location pointing
+// at the job status resource and a pending JobStatus as Data
+func (rs *Responder) AcceptedJob(jobID, statusURL string) *Response {
+	return rs.Accepted("Job accepted").
+		WithLocation(statusURL).
+		WithHeader("Content-Location", statusURL).
+		WithData(JobStatus{ID: jobID, State: JobPending})
+}
+
+// PollJob builds the response of a job status resource. Unfinished jobs get
+// 200 with Retry-After when set, succeeded jobs with a result redirect to it
+// with 303 See Other, other finished jobs get 200 with their status
+func (rs *Responder) PollJob(status JobStatus) *Response {
+	switch status.State {
+	case JobSucceeded:
+		if status.ResultURL != "" {
+			return rs.SeeOther(status.ResultURL, "Job succeeded").WithData(status)
+		}
+		return rs.OK("Job succeeded").WithData(status)
+	case JobFailed:
+		return rs.OK("Job failed").WithData(status)
+	}
+
+	resp := rs.OK("Job in progress").WithData(status)
+	if status.RetryAfter > 0 {
+		seconds := int64((status.RetryAfter + time.Second - 1) / time.Second)
+		resp.WithHeader("Retry-After", strconv.FormatInt(seconds, 10))
+	}
+	return resp
+}
@@ -0,0 +1,252 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const mediaTypeJSONAPI = "application/vnd.api+json"
+
+// JSONAPIResource lets Data items name their JSON:API type and id. Items that
+// do not implement it use their lowercased Go type name and their "id" field
+type JSONAPIResource interface {
+	JSONAPIType() string
+	JSONAPIID() string
+}
+
+// JSONAPIRelated is implemented by Data items with relationships
+type JSONAPIRelated interface {
+	JSONAPIRelationships() map[string]JSONAPIRelationship
+}
+
+// JSONAPIIdentifier identifies a related resource
+type JSONAPIIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// JSONAPIRelationship is one relationship of a resource. Data holds a
+// JSONAPIIdentifier, a slice of them, or nil for an empty to-one relationship
+type JSONAPIRelationship struct {
+	Data  any               `json:"data"`
+	Links map[string]string `json:"links,omitempty"`
+}
+
+type jsonAPIDocument struct {
+	JSONAPI struct {
+		Version string `json:"version"`
+	} `json:"jsonapi"`
+	Data   json.RawMessage   `json:"data,omitempty"`
+	Errors []jsonAPIError    `json:"errors,omitempty"`
+	Links  map[string]string `json:"links,omitempty"`
+	Meta   map[string]any    `json:"meta,omitempty"`
+}
+
+type jsonAPIResourceObject struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id,omitempty"`
+	Attributes    map[string]json.RawMessage     `json:"attributes,omitempty"`
+	Relationships map[string]JSONAPIRelationship `json:"relationships,omitempty"`
+}
+
+type jsonAPIError struct {
+	Status string            `json:"status"`
+	Code   string            `json:"code,omitempty"`
+	Title  string            `json:"title,omitempty"`
+	Detail string            `json:"detail,omitempty"`
+	Source *jsonAPISource    `json:"source,omitempty"`
+	Links  map[string]string `json:"links,omitempty"`
+}
+
+type jsonAPISource struct {
+	Pointer string `json:"pointer"`
+}
+
+// AsJSONAPI renders the response as a JSON:API document. Clients can also ask
+// for one through content negotiation with Accept: application/vnd.api+json
+func (r *Response) AsJSONAPI() *Response {
+	r.ContentType = mediaTypeJSONAPI
+	return r
+}
+
+// jsonAPIEncoder writes Data as primary data, Errors as the errors array,
+// pagination and WithLink links as links, and pagination, trace and WithMeta
+// entries as meta. Data must encode to a JSON object or an array of objects
+var jsonAPIEncoder = EncoderFunc(func(w io.Writer, r *Response) error {
+	var doc jsonAPIDocument
+	doc.JSONAPI.Version = "1.1"
+
+	if r.Code >= 400 {
+		doc.Errors = jsonAPIErrors(r)
+	} else {
+		data, err := jsonAPIData(r)
+		if err != nil {
+			return err
+		}
+		doc.Data = data
+	}
+
+	doc.Links = jsonAPILinks(r)
+	doc.Meta = jsonAPIMeta(r)
+	return json.NewEncoder(w).Encode(doc)
+})
+
+func jsonAPIData(r *Response) (json.RawMessage, error) {
+	data := r.Data
+	if data == nil {
+		return json.RawMessage("null"), nil
+	}
+
+	if !isCollection(data) {
+		resource, err := jsonAPIResourceFor(r, data)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(resource)
+	}
+
+	v := reflect.ValueOf(data)
+	resources := make([]jsonAPIResourceObject, v.Len())
+	for i := range resources {
+		resource, err := jsonAPIResourceFor(r, v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		resources[i] = resource
+	}
+	return json.Marshal(resources)
+}
+
+func jsonAPIResourceFor(r *Response, item any) (jsonAPIResourceObject, error) {
+	raw, err := json.Marshal(r.redactedValue(item))
+	if err != nil {
+		return jsonAPIResourceObject{}, err
+	}
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &attributes); err != nil || attributes == nil {
+		return jsonAPIResourceObject{}, errors.New("JSON:API primary data must encode to objects")
+	}
+
+	resource := jsonAPIResourceObject{Attributes: attributes}
+	if res, ok := item.(JSONAPIResource); ok {
+		resource.Type = res.JSONAPIType()
+		resource.ID = res.JSONAPIID()
+	} else {
+		resource.Type = jsonAPITypeName(item)
+		resource.ID = jsonAPIRawID(attributes["id"])
+	}
+	// JSON:API reserves both names for the resource object itself
+	delete(attributes, "id")
+	delete(attributes, "type")
+
+	if related, ok := item.(JSONAPIRelated); ok {
+		resource.Relationships = related.JSONAPIRelationships()
+	}
+	return resource, nil
+}
+
+func jsonAPITypeName(item any) string {
+	t := reflect.TypeOf(item)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return "resource"
+	}
+	return strings.ToLower(t.Name())
+}
+
+// jsonAPIRawID turns a JSON string or number into a string id
+func jsonAPIRawID(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if len(raw) > 0 && raw[0] != 'n' && raw[0] != '{' && raw[0] != '[' {
+		return string(raw)
+	}
+	return ""
+}
+
+func jsonAPIErrors(r *Response) []jsonAPIError {
+	status := strconv.Itoa(r.Code)
+	var links map[string]string
+	if r.DocsURL != "" {
+		links = map[string]string{"about": r.DocsURL}
+	}
+
+	if len(r.Errors) == 0 {
+		return []jsonAPIError{{
+			Status: status,
+			Code:   r.ErrorCode,
+			Title:  http.StatusText(r.Code),
+			Detail: r.Message,
+			Links:  links,
+		}}
+	}
+
+	errs := make([]jsonAPIError, len(r.Errors))
+	for i, detail := range r.Errors {
+		code := detail.Code
+		if code == "" {
+			code = r.ErrorCode
+		}
+		errs[i] = jsonAPIError{
+			Status: status,
+			Code:   code,
+			Title:  r.Message,
+			Detail: detail.Message,
+			Links:  links,
+		}
+		if detail.Field != "" {
+			errs[i].Source = &jsonAPISource{Pointer: "/data/attributes/" + detail.Field}
+		}
+	}
+	return errs
+}
+
+func jsonAPILinks(r *Response) map[string]string {
+	links := make(map[string]string)
+	if r.PaginationData != nil && r.PaginationData.Links != nil {
+		pl := r.PaginationData.Links
+		for rel, href := range map[string]string{"first": pl.First, "prev": pl.Prev, "next": pl.Next, "last": pl.Last} {
+			if href != "" {
+				links[rel] = href
+			}
+		}
+	}
+	for _, link := range r.Links {
+		links[link.Rel] = link.Href
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return links
+}
+
+func jsonAPIMeta(r *Response) map[string]any {
+	meta := make(map[string]any)
+	maps.Copy(meta, r.Meta)
+	if r.PaginationData != nil {
+		meta["pagination"] = r.PaginationData
+	}
+	if len(r.Warnings) > 0 {
+		meta["warnings"] = r.Warnings
+	}
+	if len(r.Trace) > 0 {
+		meta["trace"] = r.Trace
+	}
+	if r.RequestID != "" {
+		meta["request_id"] = r.RequestID
+	}
+	if len(meta) == 0 {
+		return nil
+	}
+	return meta
+}
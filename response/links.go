@@ -0,0 +1,17 @@
+package response
+
+// Link advertises a related resource or an action available on the resource,
+// for hypermedia-style clients
+type Link struct {
+	Rel    string `json:"rel" xml:"rel"`
+	Href   string `json:"href" xml:"href"`
+	Method string `json:"method,omitempty" xml:"method,omitempty"`
+}
+
+// WithLink adds a link to the links section of the envelope, such as
+// WithLink("self", "/users/42", "GET") or WithLink("delete", "/users/42",
+// "DELETE"). The method may be empty for plain relations
+func (r *Response) WithLink(rel, href, method string) *Response {
+	r.Links = append(r.Links, Link{Rel: rel, Href: href, Method: method})
+	return r
+}
@@ -0,0 +1,134 @@
+package response
+
+import (
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Sort directions
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// SortField is one applied sort key
+type SortField struct {
+	Field     string `json:"field" xml:"field"`
+	Direction string `json:"direction" xml:"direction"`
+}
+
+// Filter is one applied filter
+type Filter struct {
+	Field string `json:"field" xml:"field"`
+	Value string `json:"value" xml:"value"`
+}
+
+// QueryMeta echoes the sort and filters the server actually applied
+type QueryMeta struct {
+	Sort    []SortField `json:"sort,omitempty" xml:"sort>key,omitempty"`
+	Filters []Filter    `json:"filters,omitempty" xml:"filters>filter,omitempty"`
+}
+
+// ListQuery is a parsed list request: pagination, sort and filters
+type ListQuery struct {
+	PaginationParams
+	QueryMeta
+}
+
+// ListQueryOptions restricts what ParseListQuery accepts. Empty allow lists
+// accept any field
+type ListQueryOptions struct {
+	SortFields   []string
+	FilterFields []string
+	Limits       PaginationLimits
+}
+
+// ParseListQuery reads pagination, sort and filters from the query string.
+// Sort is a comma separated list of fields, prefixed with "-" for descending
+// order (sort=-created_at,name). Filters use filter[field]=value. Fields
+// outside the allow lists are dropped
+func (rs *Responder) ParseListQuery(values url.Values, opts ...ListQueryOptions) ListQuery {
+	var o ListQueryOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	q := ListQuery{PaginationParams: rs.ParsePaginationFromQuery(values, o.Limits)}
+
+	for _, raw := range values["sort"] {
+		for _, key := range strings.Split(raw, ",") {
+			key = strings.TrimSpace(key)
+			direction := SortAsc
+			if strings.HasPrefix(key, "-") {
+				key, direction = key[1:], SortDesc
+			} else {
+				key = strings.TrimPrefix(key, "+")
+			}
+			if !allowedField(key, o.SortFields) || q.sorted(key) {
+				continue
+			}
+			q.Sort = append(q.Sort, SortField{Field: key, Direction: direction})
+		}
+	}
+
+	for key, vals := range values {
+		field, ok := filterField(key)
+		if !ok || !allowedField(field, o.FilterFields) {
+			continue
+		}
+		for _, v := range vals {
+			q.Filters = append(q.Filters, Filter{Field: field, Value: v})
+		}
+	}
+	// Map iteration is random, keep the echo stable
+	sort.SliceStable(q.Filters, func(i, j int) bool {
+		return q.Filters[i].Field < q.Filters[j].Field
+	})
+
+	return q
+}
+
+func ParseListQuery(values url.Values, opts ...ListQueryOptions) ListQuery {
+	return defaultResponder.ParseListQuery(values, opts...)
+}
+
+func (q ListQuery) sorted(field string) bool {
+	for _, s := range q.Sort {
+		if s.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// filterField extracts field from a filter[field] query key
+func filterField(key string) (string, bool) {
+	if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+		return "", false
+	}
+	field := key[len("filter[") : len(key)-1]
+	return field, field != ""
+}
+
+func allowedField(field string, allowed []string) bool {
+	if field == "" {
+		return false
+	}
+	return len(allowed) == 0 || slices.Contains(allowed, field)
+}
+
+// WithListQuery sets the pagination meta for total and echoes the applied sort
+// and filters
+func (r *Response) WithListQuery(q ListQuery, total int64) *Response {
+	r.WithPagination(q.PaginationParams, total)
+	if len(q.Sort) > 0 || len(q.Filters) > 0 {
+		meta := QueryMeta{
+			Sort:    slices.Clone(q.Sort),
+			Filters: slices.Clone(q.Filters),
+		}
+		r.QueryData = &meta
+	}
+	return r
+}
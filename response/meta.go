@@ -0,0 +1,39 @@
+package response
+
+import (
+	"encoding/xml"
+	"maps"
+	"slices"
+)
+
+// Metadata holds information about a response rather than its payload, such
+// as the serving region or the feature flags in effect
+type Metadata map[string]any
+
+// MarshalXML encodes every entry as an element named after its key, in key
+// order since encoding/xml cannot encode maps
+func (m Metadata) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(m) == 0 {
+		return nil
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		if err := e.EncodeElement(m[key], xml.StartElement{Name: xml.Name{Local: key}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// WithMeta sets a metadata entry, encoded in the "meta" object of the envelope
+// so business payloads in Data stay clean
+func (r *Response) WithMeta(key string, value any) *Response {
+	if r.Meta == nil {
+		r.Meta = make(Metadata)
+	}
+	r.Meta[key] = value
+	return r
+}
@@ -0,0 +1,139 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// responseRecorder wraps a ResponseWriter remembering what has been written
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+	sent        bool // a Response was sent through this writer
+}
+
+// WriteHeader records the final status, informational 1xx responses such as
+// 103 Early Hints are passed through without being recorded
+func (rec *responseRecorder) WriteHeader(code int) {
+	if !rec.wroteHeader && code >= 200 {
+		rec.status = code
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *responseRecorder) Write(p []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	n, err := rec.ResponseWriter.Write(p)
+	rec.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (rec *responseRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// markSent flags every responseRecorder behind w as having carried a
+// Response so Instrument does not intercept it a second time
+func markSent(w http.ResponseWriter) {
+	for {
+		switch v := w.(type) {
+		case *responseRecorder:
+			v.sent = true
+			w = v.ResponseWriter
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = v.Unwrap()
+		default:
+			return
+		}
+	}
+}
+
+// Instrument feeds responses written by handlers that do not use this package
+// to the interceptors of the default Responder
+func Instrument(next http.Handler) http.Handler {
+	return defaultResponder.Instrument(next)
+}
+
+// Instrument records the status and body size written by next and, unless
+// the handler sent a Response (whose interceptors already ran), runs the
+// interceptors with a synthesized Response describing what was written: its
+// code, content type and headers, with Content-Length set to the bytes
+// written. The OnAfterWrite hooks run for it as well. This gives uniform
+// logging and metrics across handlers that do and do not use the library
+func (rs *Responder) Instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, req)
+		if rec.sent {
+			return
+		}
+
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		headers := rec.Header().Clone()
+		headers.Set("Content-Length", strconv.Itoa(rec.bytes))
+
+		config := rs.GetConfig()
+		resp := &Response{
+			Code:        status,
+			Timestamp:   config.now(),
+			ContentType: headers.Get("Content-Type"),
+			Module:      config.DefaultModule,
+			Headers:     headers,
+			owner:       rs,
+		}
+		resp.runInterceptors(req.Context(), req)
+		resp.runAfterWrite(rec.bytes, nil)
+	})
+}
+
+// Recoverer recovers panics in next and answers with an InternalServerError
+// envelope built by the default Responder
+func Recoverer(next http.Handler) http.Handler {
+	return defaultResponder.Recoverer(next)
+}
+
+// Recoverer recovers panics in next and answers with an InternalServerError
+// envelope, running the interceptors as for any other response. The panic
+// value is added to the trace outside the production environment.
+// http.ErrAbortHandler is re-panicked, and when the handler already started
+// writing its response nothing is sent and the panic is reported as a
+// *PanicError through Config.OnInterceptorError
+func (rs *Responder) Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rec := &responseRecorder{ResponseWriter: w}
+
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+
+			if rec.wroteHeader {
+				rs.reportInterceptorError(&PanicError{Value: v})
+				return
+			}
+
+			// Built inside the deferred call so a captured stack includes the panic site
+			resp := rs.InternalServerError("Internal server error")
+			if resp.getResponseConfig().Environment != EnvProduction {
+				resp.appendTraceInternal("panic", fmt.Sprint(v))
+			}
+			_ = resp.SendWithRequest(req, rec)
+		}()
+
+		next.ServeHTTP(rec, req)
+	})
+}
@@ -0,0 +1,142 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRecoverer(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         Environment
+		writeFirst  bool
+		wantStatus  int
+		wantInTrace bool
+		wantReport  bool
+	}{
+		{"development exposes the panic", EnvDevelopment, false, http.StatusInternalServerError, true, false},
+		{"production hides the panic", EnvProduction, false, http.StatusInternalServerError, false, false},
+		{"headers already written", EnvDevelopment, true, http.StatusAccepted, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported error
+			rs := NewResponder(Config{
+				Environment:        tt.env,
+				OnInterceptorError: func(err error) { reported = err },
+			})
+			h := rs.Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.writeFirst {
+					w.WriteHeader(http.StatusAccepted)
+				}
+				panic("secret detail")
+			}))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := strings.Contains(w.Body.String(), "secret detail"); got != tt.wantInTrace {
+				t.Fatalf("panic value in body = %v, want %v: %s", got, tt.wantInTrace, w.Body)
+			}
+			if got := errors.Is(reported, ErrHandlerPanicked); got != tt.wantReport {
+				t.Fatalf("reported = %v, want a report: %v", reported, tt.wantReport)
+			}
+		})
+	}
+}
+
+// finalStatusWriter drops 1xx statuses like net/http does for the final
+// status, which httptest.ResponseRecorder would otherwise record
+type finalStatusWriter struct {
+	http.ResponseWriter
+}
+
+func (w finalStatusWriter) WriteHeader(code int) {
+	if code >= 200 {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+// recordingInterceptor remembers the status codes it saw
+type recordingInterceptor struct {
+	codes []int
+}
+
+func (ri *recordingInterceptor) Intercept(ctx context.Context, r *Response, code int) {
+	ri.codes = append(ri.codes, code)
+}
+
+func (ri *recordingInterceptor) InterceptSimple(r *Response, code int) {
+	ri.codes = append(ri.codes, code)
+}
+
+func TestInstrumentRecoverer(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    func(rs *Responder) http.HandlerFunc
+		wantStatus int
+		wantCodes  []int
+	}{
+		{
+			name: "response sent through the library",
+			handler: func(rs *Responder) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					_ = rs.Created().SendWithRequest(r, w)
+				}
+			},
+			wantStatus: http.StatusCreated,
+			wantCodes:  []int{http.StatusCreated},
+		},
+		{
+			name: "plain handler",
+			handler: func(rs *Responder) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}
+			},
+			wantStatus: http.StatusTeapot,
+			wantCodes:  []int{http.StatusTeapot},
+		},
+		{
+			name: "panic after early hints",
+			handler: func(rs *Responder) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					SendEarlyHints(w, []string{"</app.css>; rel=preload; as=style"})
+					panic("boom")
+				}
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCodes:  []int{http.StatusInternalServerError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewResponder()
+			interceptor := &recordingInterceptor{}
+			if err := rs.AddInterceptor(interceptor); err != nil {
+				t.Fatal(err)
+			}
+
+			h := rs.Instrument(rs.Recoverer(tt.handler(rs)))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(finalStatusWriter{w}, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !slices.Equal(interceptor.codes, tt.wantCodes) {
+				t.Fatalf("interceptors saw %v, want %v", interceptor.codes, tt.wantCodes)
+			}
+		})
+	}
+}
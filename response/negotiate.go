@@ -0,0 +1,118 @@
+package response
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// acceptRange is a single media range parsed from an Accept header
+type acceptRange struct {
+	typ     string
+	subtype string
+	q       float64
+}
+
+// parseAccept parses an Accept header value into its media ranges
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		mt := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mt == "" {
+			continue
+		}
+		if mt == "*" {
+			mt = "*/*"
+		}
+
+		typ, subtype, ok := strings.Cut(mt, "/")
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+
+		ranges = append(ranges, acceptRange{typ: typ, subtype: subtype, q: q})
+	}
+	return ranges
+}
+
+// quality returns the q-value the most specific matching range assigns to mt
+func quality(ranges []acceptRange, mt string) float64 {
+	typ, subtype, _ := strings.Cut(mt, "/")
+
+	best, specificity := 0.0, -1
+	for _, ar := range ranges {
+		var s int
+		switch {
+		case ar.typ == typ && ar.subtype == subtype:
+			s = 2
+		case ar.typ == typ && ar.subtype == "*":
+			s = 1
+		case ar.typ == "*" && ar.subtype == "*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			best, specificity = ar.q, s
+		}
+	}
+	return best
+}
+
+// negotiate picks the registered media type best matching the Accept header
+// Ties are broken in favour of preferred, then by registration order
+func (rs *Responder) negotiate(accept, preferred string) (string, bool) {
+	ranges := parseAccept(accept)
+
+	var chosen string
+	var chosenQ float64
+	for _, mt := range rs.Encoders() {
+		q := quality(ranges, mt)
+		if q <= 0 {
+			continue
+		}
+		if q > chosenQ || (q == chosenQ && mt == preferred) {
+			chosen, chosenQ = mt, q
+		}
+	}
+	return chosen, chosen != ""
+}
+
+// SendNegotiated picks the content type from the request Accept header among
+// the registered encoders and sends the response with the request context
+// Answers 406 Not Acceptable when no registered encoder is acceptable
+func (r *Response) SendNegotiated(req *http.Request, w http.ResponseWriter) error {
+	w.Header().Add("Vary", "Accept")
+
+	accept := strings.Join(req.Header.Values("Accept"), ",")
+	if accept != "" {
+		rs := r.responder()
+		current := normalizeMediaType(r.ContentType)
+
+		mt, ok := rs.negotiate(accept, current)
+		if !ok {
+			r.Release()
+			return rs.NotAcceptable("Not Acceptable").
+				appendTraceInternal("negotiation", "supported media types: "+strings.Join(rs.Encoders(), ", ")).
+				SendWithRequest(req, w)
+		}
+
+		// Keep parameters such as charset when the type is unchanged
+		if mt != current {
+			r.ContentType = mt
+		}
+	}
+
+	return r.SendWithRequest(req, w)
+}
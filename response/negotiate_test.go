@@ -0,0 +1,49 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendNegotiated(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		wantStatus  int
+		contentType string
+	}{
+		{"no accept header", "", http.StatusOK, "application/json"},
+		{"exact match", "application/xml", http.StatusOK, "application/xml"},
+		{"highest quality", "text/plain;q=0.5, application/xml;q=0.9", http.StatusOK, "application/xml"},
+		{"wildcard keeps the current type", "*/*", http.StatusOK, "application/json"},
+		{"subtype wildcard", "text/*", http.StatusOK, "text/plain"},
+		{"refused type", "application/json;q=0, application/xml", http.StatusOK, "application/xml"},
+		{"nothing acceptable", "image/png", http.StatusNotAcceptable, "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewResponder(Config{})
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			if err := rs.OK("listed").SendNegotiated(req, w); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.contentType) {
+				t.Fatalf("Content-Type = %q, want %s", got, tt.contentType)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept" {
+				t.Fatalf("Vary = %q, want Accept", got)
+			}
+		})
+	}
+}
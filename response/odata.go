@@ -0,0 +1,121 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+type oDataCollection struct {
+	Context  string `json:"@odata.context,omitempty"`
+	Count    *int64 `json:"@odata.count,omitempty"`
+	Value    any    `json:"value"`
+	NextLink string `json:"@odata.nextLink,omitempty"`
+}
+
+type oDataErrorBody struct {
+	Error oDataError `json:"error"`
+}
+
+type oDataError struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Target  string       `json:"target,omitempty"`
+	Details []oDataError `json:"details,omitempty"`
+}
+
+// AsOData renders the response the way OData services do, for integrations
+// that only consume OData. Collections are sent as "value" with
+// @odata.count and @odata.nextLink taken from the pagination meta, single
+// objects carry @odata.context next to their own properties, and failures use
+// the OData error object. contextURL is the @odata.context annotation and may
+// be empty
+func (r *Response) AsOData(contextURL string) *Response {
+	r.format = EncoderFunc(func(w io.Writer, r *Response) error {
+		if r.Code >= 400 {
+			return json.NewEncoder(w).Encode(oDataErrorBody{Error: oDataErrorFor(r)})
+		}
+		return encodeOData(w, r, contextURL)
+	})
+	return r
+}
+
+func encodeOData(w io.Writer, r *Response, contextURL string) error {
+	if r.Data != nil && !isCollection(r.Data) {
+		raw, err := json.Marshal(r.RedactedData())
+		if err != nil {
+			return err
+		}
+		// Single entities carry the annotation inline next to their properties
+		if len(raw) > 1 && raw[0] == '{' {
+			if contextURL == "" {
+				_, err = w.Write(append(raw, '\n'))
+				return err
+			}
+			annotation, err := json.Marshal(contextURL)
+			if err != nil {
+				return err
+			}
+			var buf bytes.Buffer
+			buf.WriteString(`{"@odata.context":`)
+			buf.Write(annotation)
+			if len(raw) > 2 {
+				buf.WriteByte(',')
+			}
+			buf.Write(raw[1:])
+			buf.WriteByte('\n')
+			_, err = w.Write(buf.Bytes())
+			return err
+		}
+	}
+
+	body := oDataCollection{Context: contextURL, Value: r.RedactedData()}
+	if p := r.PaginationData; p != nil {
+		if p.Total >= 0 {
+			body.Count = &p.Total
+		}
+		if p.Links != nil {
+			body.NextLink = p.Links.Next
+		}
+	}
+	if body.Value == nil {
+		body.Value = []any{}
+	}
+	return json.NewEncoder(w).Encode(body)
+}
+
+// isCollection reports whether data encodes to a JSON array
+func isCollection(data any) bool {
+	v := reflect.ValueOf(data)
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return true
+	}
+	return false
+}
+
+func oDataErrorFor(r *Response) oDataError {
+	code := r.ErrorCode
+	if code == "" {
+		code = strconv.Itoa(r.Code)
+	}
+	message := r.Message
+	if message == "" {
+		message = http.StatusText(r.Code)
+	}
+
+	e := oDataError{Code: code, Message: message}
+	for _, detail := range r.Errors {
+		detailCode := detail.Code
+		if detailCode == "" {
+			detailCode = code
+		}
+		e.Details = append(e.Details, oDataError{Code: detailCode, Message: detail.Message, Target: detail.Field})
+	}
+	return e
+}
@@ -0,0 +1,14 @@
+module github.com/MintzyG/FastUtilitiesNet/response/otelresponse
+
+go 1.25.3
+
+replace github.com/MintzyG/FastUtilitiesNet => ../..
+
+require (
+	github.com/MintzyG/FastUtilitiesNet v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.44.0
+	go.opentelemetry.io/otel/metric v1.44.0
+	go.opentelemetry.io/otel/trace v1.44.0
+)
+
+require github.com/cespare/xxhash/v2 v2.3.0 // indirect
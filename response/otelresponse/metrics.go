@@ -0,0 +1,103 @@
+package otelresponse
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// instrumentationName identifies the meter of this package
+const instrumentationName = "github.com/MintzyG/FastUtilitiesNet/response/otelresponse"
+
+// Metrics records response metrics through the OpenTelemetry metrics API:
+// response counts and trace entries as an interceptor, written size and send
+// duration through lifecycle hooks
+type Metrics struct {
+	responses    metric.Int64Counter
+	traceEntries metric.Int64Histogram
+	size         metric.Int64Histogram
+	duration     metric.Float64Histogram
+}
+
+// NewMetrics creates the instruments on a meter of the given provider
+func NewMetrics(mp metric.MeterProvider) (*Metrics, error) {
+	meter := mp.Meter(instrumentationName)
+	m := &Metrics{}
+
+	var err error
+	if m.responses, err = meter.Int64Counter("response.count",
+		metric.WithDescription("Responses sent, by status code and module."),
+		metric.WithUnit("{response}")); err != nil {
+		return nil, err
+	}
+	if m.traceEntries, err = meter.Int64Histogram("response.trace.entries",
+		metric.WithDescription("Trace entries per response."),
+		metric.WithUnit("{entry}")); err != nil {
+		return nil, err
+	}
+	if m.size, err = meter.Int64Histogram("response.body.size",
+		metric.WithDescription("Body bytes written per response."),
+		metric.WithUnit("By")); err != nil {
+		return nil, err
+	}
+	if m.duration, err = meter.Float64Histogram("response.send.duration",
+		metric.WithDescription("Time from building a response to having written it."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func attrs(resp *response.Response, statusCode int) metric.MeasurementOption {
+	return metric.WithAttributes(
+		attribute.Int("http.response.status_code", statusCode),
+		attribute.String("response.module", resp.Module),
+	)
+}
+
+func (m *Metrics) Intercept(ctx context.Context, resp *response.Response, statusCode int) {
+	set := attrs(resp, statusCode)
+	m.responses.Add(ctx, 1, set)
+	m.traceEntries.Record(ctx, int64(len(resp.Trace)), set)
+}
+
+func (m *Metrics) InterceptSimple(resp *response.Response, statusCode int) {
+	m.Intercept(context.Background(), resp, statusCode)
+}
+
+// Hooks records the size and duration once a response was written
+func (m *Metrics) Hooks() response.Hooks {
+	return response.Hooks{
+		OnAfterWrite: func(resp *response.Response, bytesWritten int, _ error) {
+			ctx := context.Background()
+			set := attrs(resp, resp.Code)
+			m.size.Record(ctx, int64(bytesWritten), set)
+			if !resp.Timestamp.IsZero() {
+				m.duration.Record(ctx, time.Since(resp.Timestamp).Seconds(), set)
+			}
+		},
+	}
+}
+
+// RegisterMetrics creates the metrics on mp and installs them on the given
+// Responder
+func RegisterMetrics(mp metric.MeterProvider, rs ...*response.Responder) (*Metrics, error) {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+
+	m, err := NewMetrics(mp)
+	if err != nil {
+		return nil, err
+	}
+	if err := target.AddNamedInterceptor("otel-metrics", m); err != nil {
+		return nil, err
+	}
+	target.AddHooks(m.Hooks())
+	return m, nil
+}
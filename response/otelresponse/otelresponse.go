@@ -0,0 +1,86 @@
+// Package otelresponse connects responses to OpenTelemetry tracing and metrics.
+package otelresponse
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// Interceptor records responses on the span found in the send context: the
+// status code, module, error code and estimated size become span attributes
+// and every trace entry becomes a "trace" span event carrying its prefix. 5xx responses mark the span as
+// failed
+type Interceptor struct{}
+
+func (Interceptor) Intercept(ctx context.Context, resp *response.Response, statusCode int) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.Int("http.response.status_code", statusCode),
+	}
+	if resp.Module != "" {
+		attrs = append(attrs, attribute.String("response.module", resp.Module))
+	}
+	if resp.ErrorCode != "" {
+		attrs = append(attrs, attribute.String("response.error_code", resp.ErrorCode))
+	}
+	if size, ok := resp.GetResponseStats()["size_bytes"].(int); ok {
+		attrs = append(attrs, attribute.Int("http.response.body.size", size))
+	}
+	span.SetAttributes(attrs...)
+
+	for i, line := range resp.Trace {
+		if i < len(resp.TraceEntries) && resp.TraceEntries[i].Message != "" {
+			entry := resp.TraceEntries[i]
+			attrs := []attribute.KeyValue{
+				attribute.String("message", entry.Message),
+				attribute.String("level", entry.Level.String()),
+			}
+			if entry.Prefix != "" {
+				attrs = append(attrs, attribute.String("prefix", entry.Prefix))
+			}
+			span.AddEvent("trace", trace.WithTimestamp(entry.Time), trace.WithAttributes(attrs...))
+			continue
+		}
+		span.AddEvent("trace", trace.WithAttributes(attribute.String("message", line)))
+	}
+
+	if statusCode >= 500 {
+		description := resp.Message
+		if description == "" {
+			description = strconv.Itoa(statusCode)
+		}
+		span.SetStatus(codes.Error, description)
+	}
+}
+
+// InterceptSimple does nothing, there is no span without a context
+func (Interceptor) InterceptSimple(*response.Response, int) {}
+
+// Register adds the interceptor to the given Responder
+func Register(rs ...*response.Responder) error {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+	return target.AddInterceptor(Interceptor{})
+}
+
+// WithTraceContext embeds the trace_id and span_id of the span in ctx into the
+// envelope. Responses are left unchanged when ctx carries no valid span
+func WithTraceContext(r *response.Response, ctx context.Context) *response.Response {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return r
+	}
+	return r.WithTraceIDs(sc.TraceID().String(), sc.SpanID().String())
+}
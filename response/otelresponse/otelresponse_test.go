@@ -0,0 +1,113 @@
+package otelresponse
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// recordingSpan keeps what the interceptor records
+type recordingSpan struct {
+	noop.Span
+	attrs  []attribute.KeyValue
+	events []string
+	status codes.Code
+}
+
+func (s *recordingSpan) IsRecording() bool { return true }
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) { s.attrs = append(s.attrs, kv...) }
+
+func (s *recordingSpan) AddEvent(name string, opts ...trace.EventOption) {
+	prefix := ""
+	config := trace.NewEventConfig(opts...)
+	for _, kv := range config.Attributes() {
+		if kv.Key == "prefix" {
+			prefix = kv.Value.AsString()
+		}
+	}
+	s.events = append(s.events, name+"/"+prefix)
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+// unprefixed has an entry without a prefix, as decoded from other services
+func unprefixed() *response.Response {
+	r := response.OK()
+	r.Trace = []string{"retried"}
+	r.TraceEntries = []response.TraceEntry{{Message: "retried"}}
+	return r
+}
+
+func TestInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		resp   *response.Response
+		status int
+		events []string
+		failed bool
+	}{
+		{"success", response.OK("listed").AddPrefixedTrace("cache", "miss"), http.StatusOK, []string{"trace/cache"}, false},
+		{"client error", response.BadRequest("invalid").WithErrorCode("BAD_SKU"), http.StatusBadRequest, nil, false},
+		{"server error", response.InternalServerError("boom").AddTrace("db down"), http.StatusInternalServerError, []string{"trace/trace"}, true},
+		{"unprefixed entry", unprefixed(), http.StatusOK, []string{"trace/"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := &recordingSpan{}
+			ctx := trace.ContextWithSpan(context.Background(), span)
+			Interceptor{}.Intercept(ctx, tt.resp, tt.status)
+
+			var status int64
+			for _, kv := range span.attrs {
+				if kv.Key == "http.response.status_code" {
+					status = kv.Value.AsInt64()
+				}
+			}
+			if status != int64(tt.status) {
+				t.Fatalf("status attribute = %d, want %d", status, tt.status)
+			}
+			if !slices.Equal(span.events, tt.events) {
+				t.Fatalf("events = %q, want %q", span.events, tt.events)
+			}
+			if failed := span.status == codes.Error; failed != tt.failed {
+				t.Fatalf("span failed = %v, want %v", failed, tt.failed)
+			}
+		})
+	}
+}
+
+func TestWithTraceContext(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		traceID string
+		spanID  string
+	}{
+		{"valid span", trace.ContextWithSpanContext(context.Background(), sc), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"},
+		{"no span", context.Background(), "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := WithTraceContext(response.OK(), tt.ctx)
+			if r.TraceID != tt.traceID || r.SpanID != tt.spanID {
+				t.Fatalf("ids = %q %q, want %q %q", r.TraceID, r.SpanID, tt.traceID, tt.spanID)
+			}
+		})
+	}
+}
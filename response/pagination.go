@@ -1,43 +1,139 @@
 package response
 
 import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
+// TotalUnknown is passed as the total when counting is too expensive. The
+// meta then omits total and total_pages and reports has_more instead
+const TotalUnknown int64 = -1
+
 type PaginationMeta struct {
-	Page     int   `json:"page"`
-	Limit    int   `json:"limit"`
-	Total    int64 `json:"total"`
-	HasNext  bool  `json:"has_next"`
-	HasPrev  bool  `json:"has_prev"`
-	NextPage *int  `json:"next_page,omitempty"`
-	PrevPage *int  `json:"prev_page,omitempty"`
+	Page       int   `json:"page" xml:"page"`
+	Limit      int   `json:"limit" xml:"limit"`
+	Total      int64 `json:"total" xml:"total"`
+	TotalPages int   `json:"total_pages" xml:"total_pages"`
+	Estimated  bool  `json:"estimated,omitempty" xml:"estimated,omitempty"` // Total is an approximation
+	HasNext    bool  `json:"has_next" xml:"has_next"`
+	HasPrev    bool  `json:"has_prev" xml:"has_prev"`
+	HasMore    bool  `json:"-" xml:"-"` // serialized only for unknown or estimated totals
+	NextPage   *int  `json:"next_page,omitempty" xml:"next_page,omitempty"`
+	PrevPage   *int  `json:"prev_page,omitempty" xml:"prev_page,omitempty"`
+
+	Links *PaginationLinks `json:"links,omitempty" xml:"links,omitempty"`
+}
+
+// PaginationLinks holds absolute URLs of the neighbouring pages
+type PaginationLinks struct {
+	First string `json:"first" xml:"first"`
+	Prev  string `json:"prev,omitempty" xml:"prev,omitempty"`
+	Next  string `json:"next,omitempty" xml:"next,omitempty"`
+	Last  string `json:"last,omitempty" xml:"last,omitempty"`
+}
+
+// paginationMeta has the default encoding of PaginationMeta
+type paginationMeta PaginationMeta
+
+// inexactMeta shadows the total fields of the meta for unknown or estimated
+// totals and adds has_more
+type inexactMeta struct {
+	paginationMeta
+	Total      *int64 `json:"total,omitempty" xml:"total,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty" xml:"total_pages,omitempty"`
+	HasMore    bool   `json:"has_more" xml:"has_more"`
+}
+
+// exact reports whether the meta was built from an exact total
+func (m PaginationMeta) exact() bool {
+	return m.Total != TotalUnknown && !m.Estimated
+}
+
+func (m PaginationMeta) inexact() inexactMeta {
+	in := inexactMeta{paginationMeta: paginationMeta(m), HasMore: m.HasMore}
+	if m.Estimated {
+		in.Total = &m.Total
+	}
+	return in
+}
+
+func (m PaginationMeta) MarshalJSON() ([]byte, error) {
+	if m.exact() {
+		return json.Marshal(paginationMeta(m))
+	}
+	return json.Marshal(m.inexact())
+}
+
+func (m PaginationMeta) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if m.exact() {
+		return e.EncodeElement(paginationMeta(m), start)
+	}
+	return e.EncodeElement(m.inexact(), start)
 }
 
 type PaginationParams struct {
-	Page  int `json:"page"`
-	Limit int `json:"limit"`
+	Page  int `json:"page" xml:"page"`
+	Limit int `json:"limit" xml:"limit"`
 }
 
-const (
-	defaultPage  = 1
-	defaultLimit = 20
-	maxLimit     = 100
-)
+// PaginationLimits overrides the configured pagination policy for a single
+// call. Zero fields keep the configured value
+type PaginationLimits struct {
+	DefaultPage  int
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Offset returns the number of rows to skip for the page
+func (p PaginationParams) Offset() int {
+	if p.Page < 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.Limit
+}
+
+// SQL returns the LIMIT/OFFSET clause for the page
+func (p PaginationParams) SQL() string {
+	return fmt.Sprintf("LIMIT %d OFFSET %d", p.Limit, p.Offset())
+}
+
+// ParsePaginationFromQuery reads page and limit from the query, falling back to
+// the configured defaults and capping limit at the configured maximum
+func (rs *Responder) ParsePaginationFromQuery(values url.Values, overrides ...PaginationLimits) PaginationParams {
+	config := rs.GetConfig()
+	limits := PaginationLimits{
+		DefaultPage:  config.DefaultPage,
+		DefaultLimit: config.DefaultLimit,
+		MaxLimit:     config.MaxLimit,
+	}
+	for _, o := range overrides {
+		if o.DefaultPage > 0 {
+			limits.DefaultPage = o.DefaultPage
+		}
+		if o.DefaultLimit > 0 {
+			limits.DefaultLimit = o.DefaultLimit
+		}
+		if o.MaxLimit > 0 {
+			limits.MaxLimit = o.MaxLimit
+		}
+	}
 
-func ParsePaginationFromQuery(values url.Values) PaginationParams {
 	page, err := strconv.Atoi(values.Get("page"))
 	if err != nil || page < 1 {
-		page = defaultPage
+		page = limits.DefaultPage
 	}
 
 	limit, err := strconv.Atoi(values.Get("limit"))
 	if err != nil || limit < 1 {
-		limit = defaultLimit
+		limit = limits.DefaultLimit
 	}
-	if limit > maxLimit {
-		limit = maxLimit
+	if limit > limits.MaxLimit {
+		limit = limits.MaxLimit
 	}
 
 	return PaginationParams{
@@ -46,16 +142,33 @@ func ParsePaginationFromQuery(values url.Values) PaginationParams {
 	}
 }
 
+func ParsePaginationFromQuery(values url.Values, overrides ...PaginationLimits) PaginationParams {
+	return defaultResponder.ParsePaginationFromQuery(values, overrides...)
+}
+
 func CreatePaginationMeta(params PaginationParams, total int64) PaginationMeta {
-	hasNext := params.Page < int(total)
+	if total == TotalUnknown {
+		meta := PaginationMeta{
+			Page:  params.Page,
+			Limit: params.Limit,
+			Total: TotalUnknown,
+		}
+		meta.setHasMore(false)
+		return meta
+	}
+
+	pages := totalPages(total, params.Limit)
+	hasNext := params.Page < pages
 	hasPrev := params.Page > 1
 
 	meta := PaginationMeta{
-		Page:    params.Page,
-		Limit:   params.Limit,
-		Total:   total,
-		HasNext: hasNext,
-		HasPrev: hasPrev,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      total,
+		TotalPages: pages,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
+		HasMore:    hasNext,
 	}
 
 	if hasNext {
@@ -71,8 +184,162 @@ func CreatePaginationMeta(params PaginationParams, total int64) PaginationMeta {
 	return meta
 }
 
+// setHasMore sets the navigation fields from whether another page follows
+func (m *PaginationMeta) setHasMore(hasMore bool) {
+	m.HasMore = hasMore
+	m.HasNext = hasMore
+	m.HasPrev = m.Page > 1
+
+	m.NextPage, m.PrevPage = nil, nil
+	if hasMore {
+		nextPage := m.Page + 1
+		m.NextPage = &nextPage
+	}
+	if m.HasPrev {
+		prevPage := m.Page - 1
+		m.PrevPage = &prevPage
+	}
+}
+
+// Paginate slices an in-memory collection to the requested page and computes
+// its pagination meta. Pages past the end return an empty slice
+func Paginate[T any](items []T, params PaginationParams) ([]T, PaginationMeta) {
+	meta := CreatePaginationMeta(params, int64(len(items)))
+
+	start := (params.Page - 1) * params.Limit
+	if params.Page < 1 || params.Limit < 1 || start >= len(items) {
+		return []T{}, meta
+	}
+
+	end := start + params.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], meta
+}
+
+// totalPages returns the number of pages needed for total items, at least one
+func totalPages(total int64, limit int) int {
+	if limit < 1 || total <= 0 {
+		return 1
+	}
+	return int((total + int64(limit) - 1) / int64(limit))
+}
+
 func (r *Response) WithPagination(params PaginationParams, total int64) *Response {
 	meta := CreatePaginationMeta(params, total)
 	r.PaginationData = &meta
 	return r
 }
+
+// WithEstimatedPagination paginates with an approximate total, such as a
+// planner estimate. The meta is flagged estimated and reports has_more
+// instead of total_pages
+func (r *Response) WithEstimatedPagination(params PaginationParams, estimate int64) *Response {
+	meta := CreatePaginationMeta(params, estimate)
+	meta.Estimated = true
+	r.PaginationData = &meta
+	return r
+}
+
+// WithHasMore records whether another page follows, typically found by
+// fetching one row more than the limit. Use it with TotalUnknown or an
+// estimated total, it overrides what the total implied
+func (r *Response) WithHasMore(hasMore bool) *Response {
+	if r.PaginationData == nil {
+		return r
+	}
+	r.PaginationData.setHasMore(hasMore)
+	return r
+}
+
+// WithPaginationLinks sets the pagination meta for total and adds absolute
+// first, prev, next and last URLs built from the request, keeping its other
+// query parameters
+func (r *Response) WithPaginationLinks(req *http.Request, params PaginationParams, total int64) *Response {
+	r.WithPagination(params, total)
+	links := r.PaginationData.links(req)
+	r.PaginationData.Links = &links
+	return r
+}
+
+// WithLinkHeader emits an RFC 8288 Link header with first, prev, next and last
+// relations built from the pagination data and the request URL, keeping the
+// other query parameters. last is left out unless the total is exact. Call it
+// after WithPagination
+func (r *Response) WithLinkHeader(req *http.Request) *Response {
+	meta := r.PaginationData
+	if meta == nil {
+		return r
+	}
+
+	links := meta.links(req)
+	values := []string{linkValue(links.First, "first")}
+	if links.Prev != "" {
+		values = append(values, linkValue(links.Prev, "prev"))
+	}
+	if links.Next != "" {
+		values = append(values, linkValue(links.Next, "next"))
+	}
+	if links.Last != "" {
+		values = append(values, linkValue(links.Last, "last"))
+	}
+
+	return r.AddHeader("Link", strings.Join(values, ", "))
+}
+
+// links builds the URLs of the pages around this one
+func (m *PaginationMeta) links(req *http.Request) PaginationLinks {
+	links := PaginationLinks{First: pageURL(req, 1, m.Limit)}
+	if m.PrevPage != nil {
+		links.Prev = pageURL(req, *m.PrevPage, m.Limit)
+	}
+	if m.NextPage != nil {
+		links.Next = pageURL(req, *m.NextPage, m.Limit)
+	}
+	if m.exact() {
+		links.Last = pageURL(req, m.TotalPages, m.Limit)
+	}
+	return links
+}
+
+// pageURL returns the absolute request URL pointing at another page
+func pageURL(req *http.Request, page, limit int) string {
+	u := *req.URL
+	if u.Host == "" {
+		u.Host = req.Host
+	}
+	if u.Scheme == "" {
+		u.Scheme = "http"
+		if req.TLS != nil {
+			u.Scheme = "https"
+		}
+	}
+
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func linkValue(url, rel string) string {
+	return "<" + url + `>; rel="` + rel + `"`
+}
+
+// WithPaginatedQuery runs countFn for the total and pageFn for the page rows,
+// filling Data and the pagination meta. A failing query replaces the response
+// with the one FromError builds for the error
+func (r *Response) WithPaginatedQuery(params PaginationParams, countFn func() (int64, error), pageFn func(limit, offset int) (any, error)) *Response {
+	total, err := countFn()
+	if err != nil {
+		return r.responder().FromError(err)
+	}
+
+	data, err := pageFn(params.Limit, params.Offset())
+	if err != nil {
+		return r.responder().FromError(err)
+	}
+
+	return r.WithData(data).WithPagination(params, total)
+}
@@ -5,11 +5,70 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"sync"
 )
 
+// Decoder parses an encoded envelope back into a value
+// Generic values it produces for Data must be JSON-marshalable
+type Decoder interface {
+	Decode(data []byte, v any) error
+}
+
+// DecoderFunc adapts a function into a Decoder
+type DecoderFunc func(data []byte, v any) error
+
+func (f DecoderFunc) Decode(data []byte, v any) error {
+	return f(data, v)
+}
+
+// Thread-safe decoders registry used by ExtractData, keyed by media type
+var (
+	decoders   = map[string]Decoder{mediaTypeJSON: DecoderFunc(json.Unmarshal)}
+	decodersMu sync.RWMutex
+)
+
+// RegisterDecoder registers a decoder used by ExtractData for a media type
+func RegisterDecoder(mediaType string, dec Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[normalizeMediaType(mediaType)] = dec
+}
+
+// SupportedMediaTypes returns the media types with a registered decoder,
+// JSON first and the rest sorted
+func SupportedMediaTypes() []string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	types := make([]string, 0, len(decoders))
+	for mt := range decoders {
+		if mt != mediaTypeJSON {
+			types = append(types, mt)
+		}
+	}
+	sort.Strings(types)
+	return append([]string{mediaTypeJSON}, types...)
+}
+
+// decoderFor picks the decoder for a content type, defaulting to JSON
+func decoderFor(contentType string) Decoder {
+	mt := normalizeMediaType(contentType)
+
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	if dec, ok := decoders[mt]; ok {
+		return dec
+	}
+	return decoders[mediaTypeJSON]
+}
+
 // ExtractData parses an http.Response into your Response struct and also
 // unmarshals the Data field into the provided target model.
 // target must be a pointer (to struct or slice).
+// The body is decoded with the decoder registered for its Content-Type.
+// Traces are read as strings or as StructuredTrace entries.
 func ExtractData(httpResp *http.Response, target any) (*Response, error) {
 	if httpResp == nil {
 		return nil, fmt.Errorf("http response is nil")
@@ -23,10 +82,15 @@ func ExtractData(httpResp *http.Response, target any) (*Response, error) {
 	}
 
 	// Unmarshal into wrapper Response
-	var r Response
-	if err := json.Unmarshal(body, &r); err != nil {
+	var decoded decodedResponse
+	dec := decoderFor(httpResp.Header.Get("Content-Type"))
+	if err := dec.Decode(body, &decoded); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal into Response: %w", err)
 	}
+	r := Response(decoded.responseFields)
+	if err := r.setDecodedTrace(decoded.Trace); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Response.Trace: %w", err)
+	}
 
 	// If no Data, return as is
 	if r.Data == nil {
@@ -46,3 +110,42 @@ func ExtractData(httpResp *http.Response, target any) (*Response, error) {
 
 	return &r, nil
 }
+
+// responseFields is Response without its methods, so decoding ignores the
+// custom envelope encoding
+type responseFields Response
+
+// decodedResponse is the envelope as read by ExtractData. The trace holds
+// strings, or entry objects when the server uses Config.StructuredTrace
+type decodedResponse struct {
+	responseFields
+	Trace []any `json:"trace,omitempty"`
+}
+
+// setDecodedTrace fills Trace and, for entry objects, TraceEntries
+func (r *Response) setDecodedTrace(trace []any) error {
+	for _, item := range trace {
+		if line, ok := item.(string); ok {
+			r.Trace = append(r.Trace, line)
+			continue
+		}
+
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		var entry TraceEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+
+		line := entry.Message
+		if entry.Prefix != "" {
+			line = entry.Prefix + ": " + line
+		}
+		r.alignTraceEntries()
+		r.Trace = append(r.Trace, line)
+		r.TraceEntries = append(r.TraceEntries, entry)
+	}
+	return nil
+}
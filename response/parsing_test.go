@@ -0,0 +1,91 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestExtractData(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"string trace", Config{}},
+		{"structured trace", Config{StructuredTrace: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewResponder(tt.config)
+			w := httptest.NewRecorder()
+			err := rs.OK("Found").
+				WithData(user{ID: 7, Name: "Ada"}).
+				AddPrefixedTrace("db", "cache miss").
+				Send(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got user
+			resp, err := ExtractData(w.Result(), &got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != (user{ID: 7, Name: "Ada"}) {
+				t.Fatalf("Data = %+v", got)
+			}
+			if resp.Code != http.StatusOK || resp.Message != "Found" {
+				t.Fatalf("envelope = %d %q", resp.Code, resp.Message)
+			}
+			if want := []string{"db: cache miss"}; !reflect.DeepEqual(resp.Trace, want) {
+				t.Fatalf("Trace = %q, want %q", resp.Trace, want)
+			}
+		})
+	}
+}
+
+func TestMixedTrace(t *testing.T) {
+	build := func(rs *Responder) *Response {
+		r := rs.OK().AddPrefixedTrace("a", "first")
+		r.Trace = append(r.Trace, "set directly")
+		return r.AddPrefixedTrace("p", "y")
+	}
+	want := []string{"a: first", "set directly", "p: y"}
+
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"string trace", Config{}},
+		{"structured trace", Config{StructuredTrace: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewResponder(tt.config)
+			r := build(rs)
+			if len(r.TraceEntries) != len(r.Trace) || r.TraceEntries[2].Prefix != "p" {
+				t.Fatalf("entries out of step with the trace: %+v", r.TraceEntries)
+			}
+
+			w := httptest.NewRecorder()
+			if err := r.Send(w); err != nil {
+				t.Fatal(err)
+			}
+			resp, err := ExtractData(w.Result(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(resp.Trace, want) {
+				t.Fatalf("Trace = %q, want %q", resp.Trace, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package response
+
+import (
+	"net/http"
+	"sync"
+)
+
+var responsePool = sync.Pool{
+	New: func() any {
+		return new(Response)
+	},
+}
+
+// Acquire returns a pooled 200 OK response from the default Responder
+// Pooled responses are released automatically after being sent
+func Acquire(msg ...string) *Response {
+	return defaultResponder.Acquire(msg...)
+}
+
+// Acquire returns a pooled 200 OK response bound to this Responder
+// The status can be changed with the chainable status methods (e.g. NotFound)
+func (rs *Responder) Acquire(msg ...string) *Response {
+	r := responsePool.Get().(*Response)
+
+	config := rs.GetConfig()
+	r.Code = http.StatusOK
+	r.Timestamp = config.now()
+	r.ContentType = config.DefaultContentType
+	r.Module = config.DefaultModule
+	r.owner = rs
+	r.pooled = true
+	return rs.built(r.applyMessage(msg...))
+}
+
+// Release returns a pooled response to the pool, it does nothing for responses
+// not obtained through Acquire. The response must not be used afterwards
+func (r *Response) Release() {
+	if !r.pooled {
+		return
+	}
+
+	// Keep the trace backing arrays to avoid reallocating them
+	*r = Response{Trace: r.Trace[:0], TraceEntries: r.TraceEntries[:0]}
+	responsePool.Put(r)
+}
@@ -0,0 +1,55 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAcquireRelease(t *testing.T) {
+	rs := NewResponder(Config{DefaultModule: "orders"})
+
+	tests := []struct {
+		name    string
+		use     func(*Response)
+		release bool
+	}{
+		{"explicit release", func(r *Response) {}, true},
+		{"released on send", func(r *Response) {
+			if err := r.Send(httptest.NewRecorder()); err != nil {
+				t.Fatal(err)
+			}
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rs.Acquire("first").WithCode(http.StatusConflict).
+				WithData(map[string]int{"id": 1}).
+				WithHeader("X-Order", "1").
+				AddTrace("locked")
+			tt.use(r)
+			if tt.release {
+				r.Release()
+			}
+			if r.Code != 0 || r.Data != nil || len(r.Trace) != 0 || r.owner != nil {
+				t.Fatalf("released response kept its state: %+v", r)
+			}
+
+			next := rs.Acquire()
+			defer next.Release()
+			if next.Code != http.StatusOK || next.Module != "orders" || next.responder() != rs {
+				t.Fatalf("Acquire = code %d module %q, want a fresh 200 from the Responder", next.Code, next.Module)
+			}
+			if next.Message != "" || next.Data != nil || len(next.Trace) != 0 || len(next.TraceEntries) != 0 || len(next.Headers) != 0 {
+				t.Fatalf("Acquire returned leftover state: %+v", next)
+			}
+		})
+	}
+
+	plain := rs.OK("kept")
+	plain.Release()
+	if plain.Code != http.StatusOK || plain.Message != "kept" {
+		t.Fatal("Release reset a response that was not acquired")
+	}
+}
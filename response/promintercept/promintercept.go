@@ -0,0 +1,114 @@
+// Package promintercept exports response metrics as Prometheus collectors.
+package promintercept
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// Metrics holds the response collectors. It is both a prometheus.Collector
+// and a response interceptor
+type Metrics struct {
+	// Responses counts sent responses by status code and module
+	Responses *prometheus.CounterVec
+	// TraceEntries observes the number of trace entries per response
+	TraceEntries *prometheus.HistogramVec
+	// Size observes the body bytes written per response
+	Size *prometheus.HistogramVec
+	// Duration observes the time from building a response to having written it
+	Duration *prometheus.HistogramVec
+}
+
+// New creates the collectors under the given namespace
+func New(namespace string) *Metrics {
+	return &Metrics{
+		Responses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "responses_total",
+			Help:      "Responses sent, by status code and module.",
+		}, []string{"code", "module"}),
+		TraceEntries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "response_trace_entries",
+			Help:      "Trace entries per response.",
+			Buckets:   []float64{0, 1, 2, 5, 10, 20, 50},
+		}, []string{"module"}),
+		Size: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "response_size_bytes",
+			Help:      "Body bytes written per response.",
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 8),
+		}, []string{"code", "module"}),
+		Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "response_send_duration_seconds",
+			Help:      "Time from building a response to having written it.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"code", "module"}),
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.Responses.Describe(ch)
+	m.TraceEntries.Describe(ch)
+	m.Size.Describe(ch)
+	m.Duration.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.Responses.Collect(ch)
+	m.TraceEntries.Collect(ch)
+	m.Size.Collect(ch)
+	m.Duration.Collect(ch)
+}
+
+func (m *Metrics) Intercept(_ context.Context, resp *response.Response, statusCode int) {
+	m.InterceptSimple(resp, statusCode)
+}
+
+// InterceptSimple counts the response and its trace entries
+func (m *Metrics) InterceptSimple(resp *response.Response, statusCode int) {
+	m.Responses.WithLabelValues(strconv.Itoa(statusCode), resp.Module).Inc()
+	m.TraceEntries.WithLabelValues(resp.Module).Observe(float64(len(resp.Trace)))
+}
+
+// Hooks observes the size and duration once a response was written
+func (m *Metrics) Hooks() response.Hooks {
+	return response.Hooks{OnAfterWrite: m.observeWrite}
+}
+
+func (m *Metrics) observeWrite(resp *response.Response, bytesWritten int, _ error) {
+	code := strconv.Itoa(resp.Code)
+	m.Size.WithLabelValues(code, resp.Module).Observe(float64(bytesWritten))
+	if !resp.Timestamp.IsZero() {
+		m.Duration.WithLabelValues(code, resp.Module).Observe(time.Since(resp.Timestamp).Seconds())
+	}
+}
+
+// Register creates the metrics, registers them with reg (the default
+// Prometheus registerer if nil) and installs them on the given Responder
+func Register(namespace string, reg prometheus.Registerer, rs ...*response.Responder) (*Metrics, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+
+	m := New(namespace)
+	if err := reg.Register(m); err != nil {
+		return nil, err
+	}
+	if err := target.AddNamedInterceptor("prometheus", m); err != nil {
+		reg.Unregister(m)
+		return nil, err
+	}
+	target.AddHooks(m.Hooks())
+	return m, nil
+}
@@ -0,0 +1,73 @@
+package promintercept
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+func TestRegister(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	rs := response.NewResponder(response.Config{DefaultModule: "orders"})
+	if _, err := Register("shop", reg, rs); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, resp := range []*response.Response{rs.OK("listed"), rs.OK("listed"), rs.NotFound("gone").AddTrace("lookup")} {
+		if err := resp.Send(httptest.NewRecorder()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]*dto.MetricFamily, len(families))
+	for _, f := range families {
+		byName[f.GetName()] = f
+	}
+
+	tests := []struct {
+		name   string
+		family string
+		code   string
+		want   uint64
+	}{
+		{"ok responses", "shop_responses_total", "200", 2},
+		{"not found responses", "shop_responses_total", "404", 1},
+		{"ok sizes", "shop_response_size_bytes", "200", 2},
+		{"ok durations", "shop_response_send_duration_seconds", "200", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ok := byName[tt.family]
+			if !ok {
+				t.Fatalf("%s was not gathered", tt.family)
+			}
+			for _, m := range f.GetMetric() {
+				labels := map[string]string{}
+				for _, l := range m.GetLabel() {
+					labels[l.GetName()] = l.GetValue()
+				}
+				if labels["code"] != tt.code || labels["module"] != "orders" {
+					continue
+				}
+				got := uint64(m.GetCounter().GetValue())
+				if m.GetHistogram() != nil {
+					got = m.GetHistogram().GetSampleCount()
+				}
+				if got != tt.want {
+					t.Fatalf("%s{code=%q} = %d, want %d", tt.family, tt.code, got, tt.want)
+				}
+				return
+			}
+			t.Fatalf("%s has no series for code %s", tt.family, tt.code)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendRange(t *testing.T) {
+	const content = "0123456789"
+
+	tests := []struct {
+		name         string
+		method       string
+		rangeHeader  string
+		ifRange      string
+		wantStatus   int
+		wantBody     string
+		contentRange string
+	}{
+		{"full content", http.MethodGet, "", "", http.StatusOK, content, ""},
+		{"closed range", http.MethodGet, "bytes=2-4", "", http.StatusPartialContent, "234", "bytes 2-4/10"},
+		{"open range", http.MethodGet, "bytes=7-", "", http.StatusPartialContent, "789", "bytes 7-9/10"},
+		{"suffix range", http.MethodGet, "bytes=-3", "", http.StatusPartialContent, "789", "bytes 7-9/10"},
+		{"end past the content", http.MethodGet, "bytes=8-20", "", http.StatusPartialContent, "89", "bytes 8-9/10"},
+		{"multiple ranges", http.MethodGet, "bytes=0-1,4-5", "", http.StatusOK, content, ""},
+		{"malformed range", http.MethodGet, "items=0-1", "", http.StatusOK, content, ""},
+		{"unsatisfiable", http.MethodGet, "bytes=10-", "", http.StatusRequestedRangeNotSatisfiable, "", "bytes */10"},
+		{"matching If-Range", http.MethodGet, "bytes=0-1", `"v1"`, http.StatusPartialContent, "01", "bytes 0-1/10"},
+		{"stale If-Range", http.MethodGet, "bytes=0-1", `"v0"`, http.StatusOK, content, ""},
+		{"head request", http.MethodHead, "bytes=2-4", "", http.StatusPartialContent, "", "bytes 2-4/10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/file", nil)
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+			if tt.ifRange != "" {
+				req.Header.Set("If-Range", tt.ifRange)
+			}
+			w := httptest.NewRecorder()
+			w.Header().Set("ETag", `"v1"`)
+
+			err := NewResponder(Config{}).SendRange(w, req, strings.NewReader(content), int64(len(content)), "text/plain")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Range"); got != tt.contentRange {
+				t.Fatalf("Content-Range = %q, want %q", got, tt.contentRange)
+			}
+			if tt.wantStatus != http.StatusRequestedRangeNotSatisfiable && w.Body.String() != tt.wantBody {
+				t.Fatalf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
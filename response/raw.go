@@ -0,0 +1,53 @@
+package response
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Raw sends only Data, without the envelope, for endpoints whose contract
+// predates it. Status, headers, interceptors and size checks are unchanged.
+// Config.EnvelopeDisabled does the same for every response, or for a single
+// route through ContextWithConfig
+func (r *Response) Raw() *Response {
+	r.raw = true
+	return r
+}
+
+// SendRaw sends only Data, see Raw
+func (r *Response) SendRaw(w http.ResponseWriter) error {
+	return r.Raw().Send(w)
+}
+
+// rawEncoder writes Data alone in the response content type. Byte slices are
+// written as they are, plain text is formatted with fmt and any other type
+// not XML is encoded as JSON
+var rawEncoder = EncoderFunc(func(w io.Writer, r *Response) error {
+	switch data := r.Data.(type) {
+	case nil:
+		return nil
+	case []byte:
+		_, err := w.Write(data)
+		return err
+	}
+
+	switch {
+	case isXMLMediaType(r.ContentType):
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		root := xml.StartElement{Name: xml.Name{Local: r.getResponseConfig().XMLRootName}}
+		if err := xml.NewEncoder(w).EncodeElement(r.Data, root); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	case normalizeMediaType(r.ContentType) == mediaTypePlain:
+		_, err := fmt.Fprintln(w, r.RedactedData())
+		return err
+	}
+	return json.NewEncoder(w).Encode(r.RedactedData())
+})
@@ -0,0 +1,231 @@
+package response
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// RedactionRule scrubs every match of Pattern, replacing it with Replacement
+// which may refer to submatches as in regexp.ReplaceAllString
+type RedactionRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// Built-in rules for common secrets
+var (
+	RedactEmails = RedactionRule{
+		Pattern:     regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		Replacement: "[REDACTED]",
+	}
+	RedactBearerTokens = RedactionRule{
+		Pattern:     regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9\-._~+/]+=*`),
+		Replacement: "$1 [REDACTED]",
+	}
+	RedactCardNumbers = RedactionRule{
+		Pattern:     regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		Replacement: "[REDACTED]",
+	}
+)
+
+const redacted = "[REDACTED]"
+
+// redactions is the redaction registry of a Responder
+type redactions struct {
+	rules []RedactionRule
+	keys  map[string]bool
+}
+
+func (rd *redactions) empty() bool {
+	return len(rd.rules) == 0 && len(rd.keys) == 0
+}
+
+// AddRedaction registers rules applied to the message, trace and error
+// details of every response rendered by this Responder, and to Data when
+// Config.RedactData is set (see RedactedData). Responses are redacted on a
+// copy right before encoding so interceptors still see the original values
+func (rs *Responder) AddRedaction(rules ...RedactionRule) {
+	rs.redactionsMu.Lock()
+	defer rs.redactionsMu.Unlock()
+	rs.redactions.rules = append(rs.redactions.rules, rules...)
+}
+
+// RedactKeys registers sensitive field names, matched case-insensitively.
+// Their values are scrubbed from key=value and "key": "value" pairs in text,
+// and from Data objects when Config.RedactData is set
+func (rs *Responder) RedactKeys(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	quoted := make([]string, len(keys))
+	for i, k := range keys {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	rule := RedactionRule{
+		Pattern:     regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)("?\s*[:=]\s*"?)[^\s"',&;]+`),
+		Replacement: "${1}${2}" + redacted,
+	}
+
+	rs.redactionsMu.Lock()
+	defer rs.redactionsMu.Unlock()
+	if rs.redactions.keys == nil {
+		rs.redactions.keys = make(map[string]bool)
+	}
+	for _, k := range keys {
+		rs.redactions.keys[strings.ToLower(k)] = true
+	}
+	rs.redactions.rules = append(rs.redactions.rules, rule)
+}
+
+// AddRedaction registers redaction rules on the default Responder
+func AddRedaction(rules ...RedactionRule) {
+	defaultResponder.AddRedaction(rules...)
+}
+
+// RedactKeys registers sensitive field names on the default Responder
+func RedactKeys(keys ...string) {
+	defaultResponder.RedactKeys(keys...)
+}
+
+func (rs *Responder) getRedactions() redactions {
+	rs.redactionsMu.RLock()
+	defer rs.redactionsMu.RUnlock()
+	return rs.redactions
+}
+
+func (rd *redactions) text(s string) string {
+	for _, rule := range rd.rules {
+		s = rule.Pattern.ReplaceAllString(s, rule.Replacement)
+	}
+	return s
+}
+
+// redacted returns the response with the registered redactions applied to
+// its message, trace, stack, warnings and error messages, copying it when
+// there is anything to redact. Data is redacted by the encoders, see
+// RedactedData
+func (r *Response) redacted() *Response {
+	rd := r.responder().getRedactions()
+	if rd.empty() {
+		return r
+	}
+
+	c := *r
+	c.Message = rd.text(r.Message)
+
+	if r.Trace != nil {
+		c.Trace = make([]string, len(r.Trace))
+		for i, line := range r.Trace {
+			c.Trace[i] = rd.text(line)
+		}
+	}
+	if r.TraceEntries != nil {
+		c.TraceEntries = make([]TraceEntry, len(r.TraceEntries))
+		for i, entry := range r.TraceEntries {
+			entry.Prefix = rd.text(entry.Prefix)
+			entry.Message = rd.text(entry.Message)
+			c.TraceEntries[i] = entry
+		}
+	}
+	if r.Stack != nil {
+		c.Stack = make([]string, len(r.Stack))
+		for i, frame := range r.Stack {
+			c.Stack[i] = rd.text(frame)
+		}
+	}
+	if r.Warnings != nil {
+		c.Warnings = make([]string, len(r.Warnings))
+		for i, warning := range r.Warnings {
+			c.Warnings[i] = rd.text(warning)
+		}
+	}
+	if r.Errors != nil {
+		c.Errors = make([]ErrorDetail, len(r.Errors))
+		for i, detail := range r.Errors {
+			detail.Message = rd.text(detail.Message)
+			c.Errors[i] = detail
+		}
+	}
+	return &c
+}
+
+// RedactedData returns Data with the redaction rules applied when
+// Config.RedactData is set, and Data itself otherwise. Redaction works on the
+// JSON form of Data, so the result is made of maps and slices: the JSON based
+// encoders use it, while XML, HTML templates and CSV (which redacts its cells
+// instead) receive Data with its own types
+func (r *Response) RedactedData() any {
+	return r.redactedValue(r.Data)
+}
+
+// redactedValue applies the Data redaction to any value, such as stream items
+func (r *Response) redactedValue(v any) any {
+	if v == nil || !r.getResponseConfig().RedactData {
+		return v
+	}
+	rd := r.responder().getRedactions()
+	if rd.empty() {
+		return v
+	}
+	return rd.data(v)
+}
+
+// data redacts a value through its JSON form
+func (rd *redactions) data(data any) any {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return data
+	}
+	return rd.value(generic)
+}
+
+func (rd *redactions) value(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if rd.keys[strings.ToLower(k)] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = rd.value(field)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = rd.value(item)
+		}
+		return v
+	case string:
+		return rd.text(v)
+	}
+	return v
+}
+
+// row redacts a CSV record, header holding the column names
+func (rd *redactions) row(header, row []string) {
+	for i, cell := range row {
+		if i < len(header) && rd.keys[strings.ToLower(header[i])] {
+			row[i] = redacted
+			continue
+		}
+		row[i] = rd.text(cell)
+	}
+}
+
+func isXMLMediaType(contentType string) bool {
+	mt := normalizeMediaType(contentType)
+	return mt == mediaTypeXML || mt == "text/xml" || strings.HasSuffix(mt, "+xml")
+}
+
+// Redacted returns the response with the registered redactions applied, for
+// sinks outside the send path such as audit logs. The receiver is not modified
+func (r *Response) Redacted() *Response {
+	return r.redacted()
+}
@@ -0,0 +1,120 @@
+package response
+
+import (
+	"html/template"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type account struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (a account) Masked() string { return "account" }
+
+func redactingResponder() *Responder {
+	rs := NewResponder(Config{RedactData: true})
+	rs.AddRedaction(RedactEmails)
+	rs.RedactKeys("password")
+	return rs
+}
+
+func TestRedactedEncodings(t *testing.T) {
+	accounts := []account{{Email: "ada@example.com", Password: "hunter2"}}
+
+	tests := []struct {
+		name     string
+		send     func(rs *Responder, w *httptest.ResponseRecorder) error
+		wantKeep string // text the output must still contain
+	}{
+		{
+			name: "json",
+			send: func(rs *Responder, w *httptest.ResponseRecorder) error {
+				return rs.OK("Contact ada@example.com").WithData(accounts).Send(w)
+			},
+			wantKeep: `"password":"[REDACTED]"`,
+		},
+		{
+			name: "raw",
+			send: func(rs *Responder, w *httptest.ResponseRecorder) error {
+				return rs.OK().WithData(accounts).SendRaw(w)
+			},
+			wantKeep: `"password":"[REDACTED]"`,
+		},
+		{
+			name: "csv",
+			send: func(rs *Responder, w *httptest.ResponseRecorder) error {
+				return rs.OK().WithData(accounts).SendCSV(w)
+			},
+			wantKeep: "email,password\n[REDACTED],[REDACTED]\n",
+		},
+		{
+			name: "csv rows",
+			send: func(rs *Responder, w *httptest.ResponseRecorder) error {
+				return rs.OK().WithData([][]string{{"password"}, {"hunter2"}}).SendCSV(w)
+			},
+			wantKeep: "password\n[REDACTED]\n",
+		},
+		{
+			name: "html keeps struct fields and methods",
+			send: func(rs *Responder, w *httptest.ResponseRecorder) error {
+				tmpl := template.Must(template.New("page").Parse(`{{range .Data}}{{.Masked}}{{end}}`))
+				rs.RegisterHTMLRenderer(NewHTMLRenderer(tmpl))
+				return rs.OK().WithData(accounts).WithContentType("text/html").Send(w)
+			},
+			wantKeep: "account",
+		},
+		{
+			name: "stream",
+			send: func(rs *Responder, w *httptest.ResponseRecorder) error {
+				items := make(chan any, 1)
+				items <- accounts[0]
+				close(items)
+				return rs.OK("Contact ada@example.com").SendStream(w, items)
+			},
+			wantKeep: `"password":"[REDACTED]"`,
+		},
+		{
+			name: "structured trace prefix",
+			send: func(rs *Responder, w *httptest.ResponseRecorder) error {
+				return rs.BadRequest().WithConfig(Config{StructuredTrace: true}).
+					AddPrefixedTrace("ada@example.com", "mailbox full").Send(w)
+			},
+			wantKeep: `"prefix":"[REDACTED]"`,
+		},
+		{
+			name: "stack",
+			send: func(rs *Responder, w *httptest.ResponseRecorder) error {
+				r := rs.InternalServerError()
+				r.Stack = []string{"main.handler (/home/ada@example.com/app.go:10)"}
+				return r.Send(w)
+			},
+			wantKeep: "/home/[REDACTED]/app.go:10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := tt.send(redactingResponder(), w); err != nil {
+				t.Fatal(err)
+			}
+
+			body := w.Body.String()
+			for _, secret := range []string{"ada@example.com", "hunter2"} {
+				if strings.Contains(body, secret) {
+					t.Fatalf("body leaks %q: %s", secret, body)
+				}
+			}
+			if !strings.Contains(body, tt.wantKeep) {
+				t.Fatalf("body does not contain %q: %s", tt.wantKeep, body)
+			}
+		})
+	}
+
+	if accounts[0].Password != "hunter2" {
+		t.Fatal("redaction modified Data")
+	}
+}
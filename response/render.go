@@ -0,0 +1,99 @@
+package response
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Buffers larger than this are dropped instead of being returned to the pool
+const maxPooledBufferSize = 16 * 1024 * 1024
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// Render produces the final encoded payload of the response without writing it
+// anywhere, applying the same size checks used when sending
+func (r *Response) Render() ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := r.renderTo(buf); err != nil {
+		return nil, err
+	}
+
+	// The buffer goes back to the pool so hand out a copy
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// RenderString is like Render but returns the payload as a string
+func (r *Response) RenderString() (string, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := r.renderTo(buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderTo encodes the response once into buf and validates the encoded size
+func (r *Response) renderTo(buf *bytes.Buffer) error {
+	if r.raw || r.getResponseConfig().EnvelopeDisabled {
+		return r.renderWith(buf, rawEncoder)
+	}
+	if r.format != nil {
+		return r.renderWith(buf, r.format)
+	}
+	return r.renderWith(buf, r.responder().encoderFor(r.ContentType))
+}
+
+// renderWith is like renderTo but uses the given encoder
+func (r *Response) renderWith(buf *bytes.Buffer, encoder Encoder) error {
+	config := r.getResponseConfig()
+	if err := encoder.Encode(buf, r.exposed(config).redacted()); err != nil {
+		return &EncodingError{Inner: err}
+	}
+
+	return r.checkSize(buf.Len())
+}
+
+// checkSize validates an encoded size against the configured limit
+func (r *Response) checkSize(size int) error {
+	config := r.getResponseConfig()
+	if !config.EnableSizeValidation {
+		return nil
+	}
+
+	if size > config.ResponseSizeLimit {
+		return &SizeLimitError{
+			Size: size,
+			Max:  config.ResponseSizeLimit,
+		}
+	}
+
+	return nil
+}
+
+// renderFallback builds the response sent in place of one that failed to render
+func (r *Response) renderFallback(err error) *Response {
+	rs := r.responder()
+	return rs.InternalServerError("Response could not be rendered").
+		WithModule(r.Module).
+		appendTraceInternal("internal error", err)
+}
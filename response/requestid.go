@@ -0,0 +1,79 @@
+package response
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestIDHeader is the header RequestIDMiddleware reads and echoes
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied request IDs
+const maxRequestIDLength = 128
+
+// RequestIDFunc extracts a request ID from a context, returning "" if none
+type RequestIDFunc func(ctx context.Context) string
+
+type requestIDKey struct{}
+
+// ContextWithRequestID stores a request ID in the context
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in the context, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestIDMiddleware takes the request ID from the X-Request-ID header,
+// generating one when it is missing or unusable, stores it in the request
+// context and echoes it on the response. Responses sent with SendWithRequest
+// or SendWithContext then carry it as request_id, giving clients something to
+// quote in support tickets
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = randomHex(16)
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, req.WithContext(ContextWithRequestID(req.Context(), id)))
+	})
+}
+
+// validRequestID accepts short IDs of printable ASCII so client input cannot
+// smuggle anything into logs or headers
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID sets the request ID reported in the envelope
+func (r *Response) WithRequestID(id string) *Response {
+	r.RequestID = id
+	return r
+}
+
+// applyRequestID fills RequestID from the context unless it was already set,
+// using Config.RequestIDExtractor when one is configured
+func (r *Response) applyRequestID(ctx context.Context) {
+	if r.RequestID != "" || ctx == nil {
+		return
+	}
+
+	extract := r.getResponseConfig().RequestIDExtractor
+	if extract == nil {
+		extract = RequestIDFromContext
+	}
+	r.RequestID = extract(ctx)
+}
@@ -0,0 +1,287 @@
+package response
+
+import (
+	"net/http"
+	"sync"
+)
+
+// Responder owns a configuration and an interceptor registry, allowing several
+// independently configured response factories to live in the same process.
+// The package-level builders and settings operate on a default Responder.
+type Responder struct {
+	config          Config
+	configListeners []func(old, new Config)
+	configMu        sync.RWMutex
+
+	interceptors   []registeredInterceptor
+	beforeSend     []BeforeSendFunc
+	interceptorsMu sync.RWMutex
+
+	templates   map[string]*Response
+	templatesMu sync.RWMutex
+
+	errorCodes   map[string]ErrorCodeInfo
+	errorCodesMu sync.RWMutex
+
+	errorMappings   []errorMapping
+	errorMappingsMu sync.RWMutex
+
+	encoders     map[string]Encoder
+	encoderOrder []string
+	encodersMu   sync.RWMutex
+
+	redactions   redactions
+	redactionsMu sync.RWMutex
+
+	hooks   []Hooks
+	hooksMu sync.RWMutex
+
+	async asyncPool
+}
+
+// defaultResponder backs the package-level API
+var defaultResponder *Responder
+
+// Initialize with default config
+func init() {
+	defaultResponder = NewResponder()
+}
+
+// NewResponder creates a Responder using the given configuration
+// If no configuration is given the library defaults are used
+func NewResponder(cfg ...Config) *Responder {
+	rs := &Responder{
+		config: defaultConfig,
+		encoders: map[string]Encoder{
+			mediaTypeJSON:    jsonEncoder,
+			mediaTypeXML:     xmlEncoder,
+			mediaTypePlain:   plainEncoder,
+			mediaTypeJSONAPI: jsonAPIEncoder,
+		},
+		encoderOrder: []string{mediaTypeJSON, mediaTypeXML, mediaTypePlain, mediaTypeJSONAPI},
+	}
+	if len(cfg) > 0 {
+		rs.SetConfig(cfg[0])
+	}
+	rs.registerDefaultErrorMappings()
+	return rs
+}
+
+// Default returns the Responder used by the package-level functions
+func Default() *Responder {
+	return defaultResponder
+}
+
+// responder returns the Responder that created this response
+// Falls back to the default Responder for zero-value responses
+func (r *Response) responder() *Responder {
+	if r.owner != nil {
+		return r.owner
+	}
+	return defaultResponder
+}
+
+func (rs *Responder) newResponse(code int, msg ...string) *Response {
+	var message string
+	if len(msg) > 0 {
+		message = msg[0]
+	} else {
+		message = ""
+	}
+
+	config := rs.GetConfig()
+	r := &Response{
+		Code:        code,
+		Message:     message,
+		Timestamp:   config.now(),
+		ContentType: config.DefaultContentType,
+		Module:      config.DefaultModule,
+		owner:       rs,
+	}
+	return rs.built(r.captureStackFor(config))
+}
+
+// Base creates an empty response bound to this Responder
+// The given configuration is used like WithConfig, without one the response
+// follows the Responder's configuration (or the one set on the context)
+func (rs *Responder) Base(cfg ...*Config) *Response {
+	if len(cfg) > 0 && cfg[0] != nil {
+		return rs.built(&Response{
+			ContentType: cfg[0].DefaultContentType,
+			config:      *cfg[0],
+			owner:       rs,
+		})
+	}
+
+	return rs.built(&Response{
+		ContentType: rs.GetConfig().DefaultContentType,
+		owner:       rs,
+	})
+}
+
+// Standard HTTP response builders
+func (rs *Responder) OK(msg ...string) *Response {
+	return rs.newResponse(http.StatusOK, msg...)
+}
+func (rs *Responder) Created(msg ...string) *Response {
+	return rs.newResponse(http.StatusCreated, msg...)
+}
+func (rs *Responder) CreatedAt(location string, msg ...string) *Response {
+	return rs.newResponse(http.StatusCreated, msg...).WithLocation(location)
+}
+func (rs *Responder) Accepted(msg ...string) *Response {
+	return rs.newResponse(http.StatusAccepted, msg...)
+}
+func (rs *Responder) NoContent(msg ...string) *Response {
+	return rs.newResponse(http.StatusNoContent, msg...)
+}
+func (rs *Responder) NotModified(msg ...string) *Response {
+	return rs.newResponse(http.StatusNotModified, msg...)
+}
+func (rs *Responder) BadRequest(msg ...string) *Response {
+	return rs.newResponse(http.StatusBadRequest, msg...)
+}
+func (rs *Responder) Unauthorized(msg ...string) *Response {
+	return rs.newResponse(http.StatusUnauthorized, msg...)
+}
+func (rs *Responder) PaymentRequired(msg ...string) *Response {
+	return rs.newResponse(http.StatusPaymentRequired, msg...)
+}
+func (rs *Responder) Forbidden(msg ...string) *Response {
+	return rs.newResponse(http.StatusForbidden, msg...)
+}
+func (rs *Responder) NotFound(msg ...string) *Response {
+	return rs.newResponse(http.StatusNotFound, msg...)
+}
+func (rs *Responder) MethodNotAllowed(msg ...string) *Response {
+	return rs.newResponse(http.StatusMethodNotAllowed, msg...)
+}
+func (rs *Responder) NotAcceptable(msg ...string) *Response {
+	return rs.newResponse(http.StatusNotAcceptable, msg...)
+}
+func (rs *Responder) ProxyAuthRequired(msg ...string) *Response {
+	return rs.newResponse(http.StatusProxyAuthRequired, msg...)
+}
+func (rs *Responder) RequestTimeout(msg ...string) *Response {
+	return rs.newResponse(http.StatusRequestTimeout, msg...)
+}
+func (rs *Responder) Conflict(msg ...string) *Response {
+	return rs.newResponse(http.StatusConflict, msg...)
+}
+func (rs *Responder) Gone(msg ...string) *Response {
+	return rs.newResponse(http.StatusGone, msg...)
+}
+func (rs *Responder) LengthRequired(msg ...string) *Response {
+	return rs.newResponse(http.StatusLengthRequired, msg...)
+}
+func (rs *Responder) PreconditionFailed(msg ...string) *Response {
+	return rs.newResponse(http.StatusPreconditionFailed, msg...)
+}
+func (rs *Responder) RequestEntityTooLarge(msg ...string) *Response {
+	return rs.newResponse(http.StatusRequestEntityTooLarge, msg...)
+}
+func (rs *Responder) RequestURITooLong(msg ...string) *Response {
+	return rs.newResponse(http.StatusRequestURITooLong, msg...)
+}
+func (rs *Responder) UnsupportedMediaType(msg ...string) *Response {
+	return rs.newResponse(http.StatusUnsupportedMediaType, msg...).withSupportedMediaTypes()
+}
+func (rs *Responder) RequestedRangeNotSatisfiable(msg ...string) *Response {
+	return rs.newResponse(http.StatusRequestedRangeNotSatisfiable, msg...)
+}
+func (rs *Responder) ExpectationFailed(msg ...string) *Response {
+	return rs.newResponse(http.StatusExpectationFailed, msg...)
+}
+func (rs *Responder) Teapot(msg ...string) *Response {
+	return rs.newResponse(http.StatusTeapot, msg...)
+}
+func (rs *Responder) MisdirectedRequest(msg ...string) *Response {
+	return rs.newResponse(http.StatusMisdirectedRequest, msg...)
+}
+func (rs *Responder) UnprocessableEntity(msg ...string) *Response {
+	return rs.newResponse(http.StatusUnprocessableEntity, msg...)
+}
+func (rs *Responder) Locked(msg ...string) *Response {
+	return rs.newResponse(http.StatusLocked, msg...)
+}
+func (rs *Responder) FailedDependency(msg ...string) *Response {
+	return rs.newResponse(http.StatusFailedDependency, msg...)
+}
+func (rs *Responder) TooEarly(msg ...string) *Response {
+	return rs.newResponse(http.StatusTooEarly, msg...)
+}
+func (rs *Responder) UpgradeRequired(msg ...string) *Response {
+	return rs.newResponse(http.StatusUpgradeRequired, msg...)
+}
+func (rs *Responder) PreconditionRequired(msg ...string) *Response {
+	return rs.newResponse(http.StatusPreconditionRequired, msg...)
+}
+func (rs *Responder) TooManyRequests(msg ...string) *Response {
+	return rs.newResponse(http.StatusTooManyRequests, msg...)
+}
+func (rs *Responder) RequestHeaderFieldsTooLarge(msg ...string) *Response {
+	return rs.newResponse(http.StatusRequestHeaderFieldsTooLarge, msg...)
+}
+func (rs *Responder) UnavailableForLegalReasons(msg ...string) *Response {
+	return rs.newResponse(http.StatusUnavailableForLegalReasons, msg...)
+}
+func (rs *Responder) InternalServerError(msg ...string) *Response {
+	return rs.newResponse(http.StatusInternalServerError, msg...)
+}
+func (rs *Responder) NotImplemented(msg ...string) *Response {
+	return rs.newResponse(http.StatusNotImplemented, msg...)
+}
+func (rs *Responder) BadGateway(msg ...string) *Response {
+	return rs.newResponse(http.StatusBadGateway, msg...)
+}
+func (rs *Responder) ServiceUnavailable(msg ...string) *Response {
+	return rs.newResponse(http.StatusServiceUnavailable, msg...)
+}
+func (rs *Responder) GatewayTimeout(msg ...string) *Response {
+	return rs.newResponse(http.StatusGatewayTimeout, msg...)
+}
+func (rs *Responder) HTTPVersionNotSupported(msg ...string) *Response {
+	return rs.newResponse(http.StatusHTTPVersionNotSupported, msg...)
+}
+func (rs *Responder) VariantAlsoNegotiates(msg ...string) *Response {
+	return rs.newResponse(http.StatusVariantAlsoNegotiates, msg...)
+}
+func (rs *Responder) InsufficientStorage(msg ...string) *Response {
+	return rs.newResponse(http.StatusInsufficientStorage, msg...)
+}
+func (rs *Responder) LoopDetected(msg ...string) *Response {
+	return rs.newResponse(http.StatusLoopDetected, msg...)
+}
+func (rs *Responder) NotExtended(msg ...string) *Response {
+	return rs.newResponse(http.StatusNotExtended, msg...)
+}
+func (rs *Responder) NetworkAuthenticationRequired(msg ...string) *Response {
+	return rs.newResponse(http.StatusNetworkAuthenticationRequired, msg...)
+}
+
+// Status builds a response for any valid status code
+// Invalid codes produce an InternalServerError like WithCode
+func (rs *Responder) Status(code int, msg ...string) *Response {
+	if err := validateStatusCode(code); err != nil {
+		return rs.InternalServerError("Invalid status code set").
+			appendTraceInternal("error", err)
+	}
+	return rs.newResponse(code, msg...)
+}
+
+// Redirect builders, the Location header is set to location
+func (rs *Responder) MovedPermanently(location string, msg ...string) *Response {
+	return rs.newResponse(http.StatusMovedPermanently, msg...).WithLocation(location)
+}
+func (rs *Responder) Found(location string, msg ...string) *Response {
+	return rs.newResponse(http.StatusFound, msg...).WithLocation(location)
+}
+func (rs *Responder) SeeOther(location string, msg ...string) *Response {
+	return rs.newResponse(http.StatusSeeOther, msg...).WithLocation(location)
+}
+func (rs *Responder) TemporaryRedirect(location string, msg ...string) *Response {
+	return rs.newResponse(http.StatusTemporaryRedirect, msg...).WithLocation(location)
+}
+func (rs *Responder) PermanentRedirect(location string, msg ...string) *Response {
+	return rs.newResponse(http.StatusPermanentRedirect, msg...).WithLocation(location)
+}
@@ -1,47 +1,65 @@
 package response
 
 import (
+	"bytes"
+	"cmp"
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"slices"
+	"strconv"
 	"time"
 )
 
 type Response struct {
-	Module         string          `json:"module,omitempty"`
-	Message        string          `json:"message,omitempty"`
-	Data           any             `json:"data,omitempty"`
-	Trace          []string        `json:"trace,omitempty"`
-	Timestamp      time.Time       `json:"timestamp,omitempty"`
-	PaginationData *PaginationMeta `json:"pagination,omitempty"`
-	Code           int             `json:"code,omitempty"`
-	ContentType    string          `json:"-"`
-	TracePrefix    string          `json:"-"`
-	config         Config          `json:"-"`
+	Module         string           `json:"module,omitempty" xml:"module,omitempty"`
+	Message        string           `json:"message,omitempty" xml:"message,omitempty"`
+	ErrorCode      string           `json:"error_code,omitempty" xml:"error_code,omitempty"`
+	DocsURL        string           `json:"docs_url,omitempty" xml:"docs_url,omitempty"`
+	Data           any              `json:"data,omitempty" xml:"data,omitempty"`
+	Errors         []ErrorDetail    `json:"errors,omitempty" xml:"errors>error,omitempty"`
+	Warnings       []string         `json:"warnings,omitempty" xml:"warnings>warning,omitempty"`
+	TraceID        string           `json:"trace_id,omitempty" xml:"trace_id,omitempty"`
+	SpanID         string           `json:"span_id,omitempty" xml:"span_id,omitempty"`
+	RequestID      string           `json:"request_id,omitempty" xml:"request_id,omitempty"`
+	APIVersion     string           `json:"api_version,omitempty" xml:"api_version,omitempty"`
+	Trace          []string         `json:"trace,omitempty" xml:"trace>entry,omitempty"`
+	TraceEntries   []TraceEntry     `json:"-" xml:"-"`
+	Timestamp      time.Time        `json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+	Stack          []string         `json:"stack,omitempty" xml:"stack>frame,omitempty"`
+	SupportedTypes []string         `json:"supported_media_types,omitempty" xml:"supported_media_types>media_type,omitempty"`
+	Replacement    string           `json:"replacement,omitempty" xml:"replacement,omitempty"`
+	Deprecation    *DeprecationInfo `json:"deprecation,omitempty" xml:"deprecation,omitempty"`
+	PaginationData *PaginationMeta  `json:"pagination,omitempty" xml:"pagination,omitempty"`
+	QueryData      *QueryMeta       `json:"query,omitempty" xml:"query,omitempty"`
+	Timings        []Timing         `json:"timings,omitempty" xml:"timings>timing,omitempty"`
+	Meta           Metadata         `json:"meta,omitempty" xml:"meta,omitempty"`
+	Links          []Link           `json:"links,omitempty" xml:"links>link,omitempty"`
+	Code           int              `json:"code,omitempty" xml:"code,omitempty"`
+	ContentType    string           `json:"-" xml:"-"`
+	TracePrefix    string           `json:"-" xml:"-"`
+	Headers        http.Header      `json:"-" xml:"-"`
+	cookies        []*http.Cookie
+	hooks          []Hooks
+	interceptors   []registeredInterceptor
+	timers         map[string]time.Time
+	raw            bool
+	format         Encoder
+	config         Config     `json:"-"`
+	owner          *Responder `json:"-"`
+	pooled         bool       `json:"-"`
 }
 
 // WithConfig sets a custom configuration for this specific response instance
 // This overrides the global configuration for this response only
 func (r *Response) WithConfig(config Config) *Response {
-	// Validate and set defaults for invalid config values
-	if config.MaxTraceSize <= 0 {
-		config.MaxTraceSize = defaultConfig.MaxTraceSize
-	}
-	if config.ResponseSizeLimit <= 0 {
-		config.ResponseSizeLimit = defaultConfig.ResponseSizeLimit
-	}
-	if config.MaxInterceptorAmount <= 0 {
-		config.MaxInterceptorAmount = defaultConfig.MaxInterceptorAmount
-	}
-	if config.DefaultContentType == "" {
-		config.DefaultContentType = defaultConfig.DefaultContentType
-	}
-
-	r.config = config
+	r.config = normalizeConfig(config)
 
 	// Update ContentType if it wasn't explicitly set
-	if r.ContentType == "" || r.ContentType == getConfig().DefaultContentType {
-		r.ContentType = config.DefaultContentType
+	if r.ContentType == "" || r.ContentType == r.responder().GetConfig().DefaultContentType {
+		r.ContentType = r.config.DefaultContentType
 	}
 
 	return r
@@ -67,6 +85,13 @@ func (r *Response) WithData(data any) *Response {
 	return r
 }
 
+// WithWarning adds a non-fatal notice for the client, such as a deprecated
+// parameter being used or partial data being returned
+func (r *Response) WithWarning(msg string) *Response {
+	r.Warnings = append(r.Warnings, msg)
+	return r
+}
+
 func (r *Response) WithTracePrefix(prefix string) *Response {
 	r.TracePrefix = prefix
 	return r
@@ -75,7 +100,7 @@ func (r *Response) WithTracePrefix(prefix string) *Response {
 // Does nothing unless using a custom response
 func (r *Response) WithCode(code int) *Response {
 	if err := validateStatusCode(code); err != nil {
-		return InternalServerError("Invalid status code set").
+		return r.responder().InternalServerError("Invalid status code set").
 			appendTraceInternal("error", err)
 	} else {
 		r.Code = code
@@ -84,43 +109,173 @@ func (r *Response) WithCode(code int) *Response {
 }
 
 // For when you don't have context (simple cases, tests, etc.)
-func (r *Response) Send(w http.ResponseWriter) {
-	r.SendWithContext(context.Background(), w)
+// Returns an error if the response was rejected or could not be written
+func (r *Response) Send(w http.ResponseWriter) error {
+	return r.SendWithContext(context.Background(), w)
 }
 
 // For when you have context (web servers, etc.)
-func (r *Response) SendWithContext(ctx context.Context, w http.ResponseWriter) {
-	if err := r.validateResponseSize(); err != nil {
-		// Create a new error response that fits within limits
-		errorResp := r.WithCode(http.StatusInternalServerError).WithContentType(getConfig().DefaultContentType)
-		errorResp.sendInternal(ctx, w)
-		return
+// Returns an error if the response was rejected or could not be written
+// Pooled responses are released once sent
+func (r *Response) SendWithContext(ctx context.Context, w http.ResponseWriter) error {
+	return r.send(ctx, nil, w)
+}
+
+// For when you have the request (handlers, middlewares)
+// Like SendWithContext but also suppresses the body of HEAD requests
+func (r *Response) SendWithRequest(req *http.Request, w http.ResponseWriter) error {
+	return r.send(req.Context(), req, w)
+}
+
+// send is the common send path, req may be nil
+func (r *Response) send(ctx context.Context, req *http.Request, w http.ResponseWriter) error {
+	r = r.runBeforeSend(ctx)
+	r.applyContextConfig(ctx)
+	if !bodyAllowed(r.Code) {
+		return r.sendWithoutBody(ctx, req, w)
+	}
+
+	defer r.Release()
+
+	r.applyTraceParent(ctx)
+	r.applyRequestID(ctx)
+	r.applyAPIVersion(ctx)
+	r.applyDocsURL()
+	r.runInterceptors(ctx, req)
+	r.runBeforeEncode()
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	// Encode once into the pooled buffer and write those same bytes
+	if err := r.renderTo(buf); err != nil {
+		return r.sendFallback(ctx, req, w, buf, err)
 	}
 
-	r.sendInternal(ctx, w)
+	if req != nil && req.Method == http.MethodHead {
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		return r.write(w, nil)
+	}
+	return r.write(w, buf.Bytes())
+}
+
+// bodyAllowed reports whether a status may carry a body (RFC 9110)
+func bodyAllowed(code int) bool {
+	switch {
+	case code >= 100 && code < 200:
+		return false
+	case code == http.StatusNoContent, code == http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
+// sendWithoutBody writes the status and headers only, used for statuses that
+// never carry a body (1xx, 204, 304) regardless of Data or Message
+func (r *Response) sendWithoutBody(ctx context.Context, req *http.Request, w http.ResponseWriter) error {
+	defer r.Release()
+
+	r.runInterceptors(ctx, req)
+	markSent(w)
+	r.applyHeaders(w)
+	w.WriteHeader(r.Code)
+	r.runAfterWrite(0, nil)
+	return nil
+}
+
+// sendFallback sends an error response that fits within limits in place of
+// one that failed to render, returning the original rendering error
+func (r *Response) sendFallback(ctx context.Context, req *http.Request, w http.ResponseWriter, buf *bytes.Buffer, err error) error {
+	fallback := r.renderFallback(err)
+	fallback.runInterceptors(ctx, req)
+
+	buf.Reset()
+	if fallbackErr := fallback.renderTo(buf); fallbackErr != nil {
+		return errors.Join(err, fallbackErr)
+	}
+	if sendErr := fallback.write(w, buf.Bytes()); sendErr != nil {
+		return errors.Join(err, sendErr)
+	}
+	return err
 }
 
-// Internal send method to avoid code duplication
-func (r *Response) sendInternal(ctx context.Context, w http.ResponseWriter) {
-	interceptorsMu.RLock()
-	currentInterceptors := make([]ResponseInterceptor, len(interceptors))
-	copy(currentInterceptors, interceptors)
-	interceptorsMu.RUnlock()
+// runInterceptors invokes the registered interceptors for this response
+// req is nil unless the response is sent with SendWithRequest
+func (r *Response) runInterceptors(ctx context.Context, req *http.Request) {
+	rs := r.responder()
+	chain := rs.interceptorChain()
+	if len(r.interceptors) > 0 {
+		chain = append(chain, r.interceptors...)
+		slices.SortStableFunc(chain, func(a, b registeredInterceptor) int {
+			return cmp.Compare(a.priority, b.priority)
+		})
+	}
+
+	for _, reg := range chain {
+		if !reg.accepts(r, r.Code) {
+			continue
+		}
+		if reg.async {
+			rs.interceptAsync(ctx, req, reg, r)
+			continue
+		}
+		rs.callInterceptor(ctx, req, reg, r)
+	}
+}
 
-	for _, interceptor := range currentInterceptors {
-		if ctx != nil && ctx != context.Background() {
-			interceptor.Intercept(ctx, r, r.Code)
-		} else {
-			interceptor.InterceptSimple(r, r.Code)
+// callInterceptor runs one interceptor, recovering and reporting its panics
+// so a faulty interceptor never prevents the response from being sent
+func (rs *Responder) callInterceptor(ctx context.Context, req *http.Request, reg registeredInterceptor, r *Response) {
+	defer func() {
+		if v := recover(); v != nil {
+			rs.reportInterceptorError(&InterceptorError{Name: reg.name, Panic: v})
 		}
+	}()
+	dispatchInterceptor(ctx, req, reg.interceptor, r)
+}
+
+// reportInterceptorError hands err to Config.OnInterceptorError
+func (rs *Responder) reportInterceptorError(err error) {
+	if handler := rs.GetConfig().OnInterceptorError; handler != nil {
+		handler(err)
+		return
+	}
+	log.Printf("response: %v", err)
+}
+
+// dispatchInterceptor invokes the interceptor method matching what is available
+func dispatchInterceptor(ctx context.Context, req *http.Request, interceptor ResponseInterceptor, r *Response) {
+	if ra, ok := interceptor.(RequestAwareInterceptor); ok && req != nil {
+		ra.InterceptRequest(req, r, r.Code)
+	} else if ctx != nil && ctx != context.Background() {
+		interceptor.Intercept(ctx, r, r.Code)
+	} else {
+		interceptor.InterceptSimple(r, r.Code)
 	}
+}
 
+// write sends the headers and an already rendered body
+func (r *Response) write(w http.ResponseWriter, body []byte) error {
+	markSent(w)
+	r.applyHeaders(w)
 	w.Header().Set("Content-Type", r.ContentType)
 	w.WriteHeader(r.Code)
 
-	encoder := json.NewEncoder(w)
-	if err := encoder.Encode(r); err != nil {
-		// If encoding fails, we can't send the original response so we leave it to Interceptors
-		r.appendTraceInternal("internal error", (&EncodingError{Inner: err}).Error())
+	n, err := w.Write(body)
+	if err != nil {
+		err = &WriteError{Inner: err}
+	}
+	r.runAfterWrite(n, err)
+	return err
+}
+
+// Error makes a Response usable as an error so service layers can return it
+// and the HTTP layer can recover it with errors.As (FromError does this)
+// Return a literal nil, not a nil *Response, when there is no error
+func (r *Response) Error() string {
+	message := r.Message
+	if message == "" {
+		message = http.StatusText(r.Code)
 	}
+	return fmt.Sprintf("%d %s", r.Code, message)
 }
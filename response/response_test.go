@@ -0,0 +1,35 @@
+package response
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWithCode(t *testing.T) {
+	rs := NewResponder(Config{DefaultModule: "billing"})
+
+	tests := []struct {
+		name string
+		code int
+		want int
+	}{
+		{"valid code", http.StatusAccepted, http.StatusAccepted},
+		{"below range", 42, http.StatusInternalServerError},
+		{"above range", 1000, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rs.Base().WithCode(tt.code)
+			if r.Code != tt.want {
+				t.Fatalf("Code = %d, want %d", r.Code, tt.want)
+			}
+			if r.responder() != rs {
+				t.Fatal("response is no longer bound to its Responder")
+			}
+			if tt.want == http.StatusInternalServerError && r.Module != "billing" {
+				t.Fatalf("Module = %q, want the Responder's default module", r.Module)
+			}
+		})
+	}
+}
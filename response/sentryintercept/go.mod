@@ -0,0 +1,15 @@
+module github.com/MintzyG/FastUtilitiesNet/response/sentryintercept
+
+go 1.25.3
+
+replace github.com/MintzyG/FastUtilitiesNet => ../..
+
+require (
+	github.com/MintzyG/FastUtilitiesNet v0.0.0-00010101000000-000000000000
+	github.com/getsentry/sentry-go v0.43.0
+)
+
+require (
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
@@ -0,0 +1,163 @@
+// Package sentryintercept reports server error responses to Sentry.
+package sentryintercept
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"github.com/getsentry/sentry-go"
+)
+
+// Interceptor captures a Sentry event for every response at or above its
+// minimum status, 500 by default
+type Interceptor struct {
+	hub        *sentry.Hub
+	minStatus  int
+	sampleRate float64
+	window     time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// Option configures an Interceptor
+type Option func(*Interceptor)
+
+// WithMinStatus reports responses with a status code of at least minStatus
+func WithMinStatus(minStatus int) Option {
+	return func(i *Interceptor) {
+		i.minStatus = minStatus
+	}
+}
+
+// WithSampleRate reports only the given fraction of responses, between 0 and 1
+func WithSampleRate(rate float64) Option {
+	return func(i *Interceptor) {
+		i.sampleRate = min(max(rate, 0), 1)
+	}
+}
+
+// WithDedupWindow drops events whose dedup key was already reported within
+// window. Events are always fingerprinted by the key so Sentry groups them
+// into one issue either way
+func WithDedupWindow(window time.Duration) Option {
+	return func(i *Interceptor) {
+		i.window = window
+	}
+}
+
+// New creates an interceptor reporting to hub, sentry.CurrentHub() if nil.
+// A hub stored in the request context takes precedence
+func New(hub *sentry.Hub, opts ...Option) *Interceptor {
+	if hub == nil {
+		hub = sentry.CurrentHub()
+	}
+	i := &Interceptor{
+		hub:        hub,
+		minStatus:  500,
+		sampleRate: 1,
+		seen:       make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+func (i *Interceptor) Intercept(ctx context.Context, resp *response.Response, statusCode int) {
+	i.report(ctx, nil, resp, statusCode)
+}
+
+func (i *Interceptor) InterceptSimple(resp *response.Response, statusCode int) {
+	i.report(context.Background(), nil, resp, statusCode)
+}
+
+// InterceptRequest attaches the request to the event
+func (i *Interceptor) InterceptRequest(req *http.Request, resp *response.Response, statusCode int) {
+	i.report(req.Context(), req, resp, statusCode)
+}
+
+// DedupKey returns the key events are grouped by: the error code when the
+// response has one, otherwise its module and status code
+func DedupKey(resp *response.Response, statusCode int) string {
+	if resp.ErrorCode != "" {
+		return resp.ErrorCode
+	}
+	return resp.Module + ":" + strconv.Itoa(statusCode)
+}
+
+func (i *Interceptor) report(ctx context.Context, req *http.Request, resp *response.Response, statusCode int) {
+	if statusCode < i.minStatus {
+		return
+	}
+	if i.sampleRate < 1 && rand.Float64() >= i.sampleRate {
+		return
+	}
+
+	key := DedupKey(resp, statusCode)
+	if !i.firstSeen(key) {
+		return
+	}
+
+	hub := i.hub
+	if h := sentry.GetHubFromContext(ctx); h != nil {
+		hub = h
+	}
+
+	event := sentry.NewEvent()
+	event.Level = sentry.LevelError
+	event.Message = resp.Message
+	event.Fingerprint = []string{key}
+	event.Tags["module"] = resp.Module
+	event.Tags["status_code"] = strconv.Itoa(statusCode)
+	if resp.ErrorCode != "" {
+		event.Tags["error_code"] = resp.ErrorCode
+	}
+	if resp.TraceID != "" {
+		event.Tags["trace_id"] = resp.TraceID
+	}
+	if len(resp.Trace) > 0 {
+		event.Contexts["response"] = sentry.Context{"trace": resp.Trace}
+	}
+	if req != nil {
+		event.Request = sentry.NewRequest(req)
+	}
+
+	hub.CaptureEvent(event)
+}
+
+// firstSeen reports whether key is outside the dedup window and records it
+func (i *Interceptor) firstSeen(key string) bool {
+	if i.window <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if last, ok := i.seen[key]; ok && now.Sub(last) < i.window {
+		return false
+	}
+	for k, last := range i.seen {
+		if now.Sub(last) >= i.window {
+			delete(i.seen, k)
+		}
+	}
+	i.seen[key] = now
+	return true
+}
+
+// Register adds a Sentry interceptor to the given Responder
+func Register(hub *sentry.Hub, opts []Option, rs ...*response.Responder) error {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+	return target.AddNamedInterceptor("sentry", New(hub, opts...))
+}
@@ -0,0 +1,137 @@
+// Package slogintercept logs responses with log/slog.
+package slogintercept
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// Interceptor logs every response it sees as one structured record
+type Interceptor struct {
+	logger     *slog.Logger
+	message    string
+	level      func(statusCode int) slog.Level
+	traceFrom  int
+	includeLen bool
+}
+
+// Option configures an Interceptor
+type Option func(*Interceptor)
+
+// WithLevel sets how status codes map to log levels. By default 5xx log at
+// error, 4xx at warn and everything else at info
+func WithLevel(level func(statusCode int) slog.Level) Option {
+	return func(i *Interceptor) {
+		i.level = level
+	}
+}
+
+// WithTraceFrom logs the trace of responses with a status code of at least
+// minStatus, 400 by default. Use a value above 599 to never log traces
+func WithTraceFrom(minStatus int) Option {
+	return func(i *Interceptor) {
+		i.traceFrom = minStatus
+	}
+}
+
+// WithSize adds the size of the encoded envelope. It costs an extra encoding
+// of every response
+func WithSize() Option {
+	return func(i *Interceptor) {
+		i.includeLen = true
+	}
+}
+
+// WithMessage sets the log message, "response" by default
+func WithMessage(msg string) Option {
+	return func(i *Interceptor) {
+		i.message = msg
+	}
+}
+
+// DefaultLevel maps 5xx to error, 4xx to warn and the rest to info
+func DefaultLevel(statusCode int) slog.Level {
+	switch {
+	case statusCode >= 500:
+		return slog.LevelError
+	case statusCode >= 400:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
+
+// New creates an interceptor logging to logger, slog.Default() if nil
+func New(logger *slog.Logger, opts ...Option) *Interceptor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	i := &Interceptor{
+		logger:    logger,
+		message:   "response",
+		level:     DefaultLevel,
+		traceFrom: 400,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+func (i *Interceptor) Intercept(ctx context.Context, resp *response.Response, statusCode int) {
+	i.log(ctx, nil, resp, statusCode)
+}
+
+func (i *Interceptor) InterceptSimple(resp *response.Response, statusCode int) {
+	i.log(context.Background(), nil, resp, statusCode)
+}
+
+// InterceptRequest adds the request method and path to the record
+func (i *Interceptor) InterceptRequest(req *http.Request, resp *response.Response, statusCode int) {
+	i.log(req.Context(), req, resp, statusCode)
+}
+
+func (i *Interceptor) log(ctx context.Context, req *http.Request, resp *response.Response, statusCode int) {
+	level := i.level(statusCode)
+	if !i.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.Int("code", statusCode),
+		slog.String("module", resp.Module),
+	}
+	if resp.Message != "" {
+		attrs = append(attrs, slog.String("message", resp.Message))
+	}
+	if resp.ErrorCode != "" {
+		attrs = append(attrs, slog.String("error_code", resp.ErrorCode))
+	}
+	if req != nil {
+		attrs = append(attrs, slog.String("method", req.Method), slog.String("path", req.URL.Path))
+	}
+	if resp.TraceID != "" {
+		attrs = append(attrs, slog.String("trace_id", resp.TraceID))
+	}
+	if i.includeLen {
+		if size, ok := resp.GetResponseStats()["size_bytes"].(int); ok {
+			attrs = append(attrs, slog.Int("size", size))
+		}
+	}
+	if statusCode >= i.traceFrom && len(resp.Trace) > 0 {
+		attrs = append(attrs, slog.Any("trace", resp.Trace))
+	}
+
+	i.logger.LogAttrs(ctx, level, i.message, attrs...)
+}
+
+// Register adds a logging interceptor to the given Responder
+func Register(logger *slog.Logger, opts []Option, rs ...*response.Responder) error {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+	return target.AddNamedInterceptor("slog", New(logger, opts...))
+}
@@ -0,0 +1,67 @@
+package response
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Maximum number of frames recorded in a stack trace
+const maxStackFrames = 32
+
+// Frames of this package are trimmed from captured stacks
+const packagePrefix = "github.com/MintzyG/FastUtilitiesNet/response."
+
+// WithStack records the current stack trace into the stack field
+func (r *Response) WithStack() *Response {
+	r.Stack = captureStack()
+	return r
+}
+
+// captureStackFor records a stack for 5xx responses when CaptureStacks is enabled
+func (r *Response) captureStackFor(config Config) *Response {
+	if config.CaptureStacks && r.Code >= 500 {
+		r.Stack = captureStack()
+	}
+	return r
+}
+
+// captureStack returns the caller stack as "function (file:line)" entries,
+// without the runtime and library frames
+func captureStack() []string {
+	pcs := make([]uintptr, maxStackFrames+16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []string
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) && !strings.HasPrefix(frame.Function, "runtime.") {
+			stack = append(stack, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+			if len(stack) == maxStackFrames {
+				break
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// callerLocation returns "file:line" of the first caller outside this package
+func callerLocation() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) && !strings.HasPrefix(frame.Function, "runtime.") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
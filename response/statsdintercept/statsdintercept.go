@@ -0,0 +1,120 @@
+// Package statsdintercept emits response metrics in the StatsD line protocol,
+// with Datadog-style tags as understood by DogStatsD and Telegraf.
+package statsdintercept
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+// Metrics writes one StatsD packet per metric. It is a response interceptor
+type Metrics struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix string
+	tags   string
+	buf    []byte
+}
+
+var tagReplacer = strings.NewReplacer("|", "_", ":", "_", ",", "_", "#", "_", "\n", "_")
+
+// New creates metrics written to w. Every metric name gets prefix and every
+// packet carries the constant tags, given as "key:value"
+func New(w io.Writer, prefix string, tags ...string) *Metrics {
+	return &Metrics{
+		w:      w,
+		prefix: prefix,
+		tags:   strings.Join(tags, ","),
+	}
+}
+
+// Dial creates metrics sent over UDP to addr, such as "127.0.0.1:8125"
+func Dial(addr, prefix string, tags ...string) (*Metrics, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return New(conn, prefix, tags...), nil
+}
+
+// Close closes the underlying writer if it is an io.Closer
+func (m *Metrics) Close() error {
+	if c, ok := m.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (m *Metrics) Intercept(_ context.Context, resp *response.Response, statusCode int) {
+	m.InterceptSimple(resp, statusCode)
+}
+
+// InterceptSimple counts the response
+func (m *Metrics) InterceptSimple(resp *response.Response, statusCode int) {
+	m.emit("responses", "1", "c", statusCode, resp.Module)
+}
+
+// Hooks emits the size and send duration once a response was written
+func (m *Metrics) Hooks() response.Hooks {
+	return response.Hooks{OnAfterWrite: m.observeWrite}
+}
+
+func (m *Metrics) observeWrite(resp *response.Response, bytesWritten int, _ error) {
+	m.emit("response.size", strconv.Itoa(bytesWritten), "h", resp.Code, resp.Module)
+	if !resp.Timestamp.IsZero() {
+		ms := float64(time.Since(resp.Timestamp)) / float64(time.Millisecond)
+		m.emit("response.duration", strconv.FormatFloat(ms, 'f', 3, 64), "ms", resp.Code, resp.Module)
+	}
+}
+
+// emit writes "<prefix><name>:<value>|<kind>|#code:<code>,module:<module>[,tags]"
+func (m *Metrics) emit(name, value, kind string, statusCode int, module string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b := m.buf[:0]
+	b = append(b, m.prefix...)
+	b = append(b, name...)
+	b = append(b, ':')
+	b = append(b, value...)
+	b = append(b, '|')
+	b = append(b, kind...)
+	b = append(b, "|#code:"...)
+	b = strconv.AppendInt(b, int64(statusCode), 10)
+	b = append(b, ",module:"...)
+	b = append(b, tagReplacer.Replace(module)...)
+	if m.tags != "" {
+		b = append(b, ',')
+		b = append(b, m.tags...)
+	}
+	m.buf = b
+
+	// StatsD is fire and forget, a lost packet is not worth failing a response
+	_, _ = m.w.Write(b)
+}
+
+// Register dials addr and installs the metrics on the given Responder
+func Register(addr, prefix string, rs ...*response.Responder) (*Metrics, error) {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+
+	m, err := Dial(addr, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := target.AddNamedInterceptor("statsd", m); err != nil {
+		_ = m.Close()
+		return nil, err
+	}
+	target.AddHooks(m.Hooks())
+	return m, nil
+}
@@ -0,0 +1,72 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+const mediaTypeNDJSON = "application/x-ndjson"
+
+// SendStream writes the envelope (without Data) as the first line followed by
+// one JSON line per item received from items, flushing after every line
+// The stream ends when items is closed. Streams are not subject to ResponseSizeLimit
+// The header and items are redacted like Data, see RedactedData
+func (r *Response) SendStream(w http.ResponseWriter, items <-chan any) error {
+	return r.SendStreamWithContext(context.Background(), w, items)
+}
+
+// SendStreamWithContext is like SendStream but also stops when ctx is done,
+// producers should watch the same context to avoid blocking on items
+func (r *Response) SendStreamWithContext(ctx context.Context, w http.ResponseWriter, items <-chan any) error {
+	defer r.Release()
+
+	r.runInterceptors(ctx, nil)
+
+	header := r.Exposed().Clone()
+	header.Data = nil
+	line, err := json.Marshal(header)
+	if err != nil {
+		return &EncodingError{Inner: err}
+	}
+
+	markSent(w)
+	r.applyHeaders(w)
+	w.Header().Set("Content-Type", mediaTypeNDJSON)
+	w.WriteHeader(r.Code)
+
+	rc := http.NewResponseController(w)
+	if err := writeLine(w, rc, line); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-items:
+			if !ok {
+				return nil
+			}
+
+			line, err := json.Marshal(r.redactedValue(item))
+			if err != nil {
+				return &EncodingError{Inner: err}
+			}
+			if err := writeLine(w, rc, line); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeLine writes a newline terminated line and flushes it when supported
+func writeLine(w http.ResponseWriter, rc *http.ResponseController, line []byte) error {
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return &WriteError{Inner: err}
+	}
+	if err := rc.Flush(); err != nil && err != http.ErrNotSupported {
+		return &WriteError{Inner: err}
+	}
+	return nil
+}
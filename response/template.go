@@ -0,0 +1,157 @@
+package response
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"time"
+)
+
+// Clone returns a copy of the response that can be modified independently
+// The trace and pagination data are copied, Data itself is shared
+func (r *Response) Clone() *Response {
+	c := *r
+	c.pooled = false
+
+	if r.Trace != nil {
+		c.Trace = make([]string, len(r.Trace))
+		copy(c.Trace, r.Trace)
+	}
+
+	if r.TraceEntries != nil {
+		c.TraceEntries = make([]TraceEntry, len(r.TraceEntries))
+		copy(c.TraceEntries, r.TraceEntries)
+	}
+
+	if r.Headers != nil {
+		c.Headers = r.Headers.Clone()
+	}
+
+	if r.cookies != nil {
+		c.cookies = make([]*http.Cookie, len(r.cookies))
+		copy(c.cookies, r.cookies)
+	}
+
+	if r.SupportedTypes != nil {
+		c.SupportedTypes = make([]string, len(r.SupportedTypes))
+		copy(c.SupportedTypes, r.SupportedTypes)
+	}
+
+	if r.Stack != nil {
+		c.Stack = make([]string, len(r.Stack))
+		copy(c.Stack, r.Stack)
+	}
+
+	if r.Errors != nil {
+		c.Errors = make([]ErrorDetail, len(r.Errors))
+		copy(c.Errors, r.Errors)
+	}
+
+	if r.Warnings != nil {
+		c.Warnings = make([]string, len(r.Warnings))
+		copy(c.Warnings, r.Warnings)
+	}
+
+	if r.interceptors != nil {
+		c.interceptors = make([]registeredInterceptor, len(r.interceptors))
+		copy(c.interceptors, r.interceptors)
+	}
+
+	if r.hooks != nil {
+		c.hooks = make([]Hooks, len(r.hooks))
+		copy(c.hooks, r.hooks)
+	}
+
+	if r.Timings != nil {
+		c.Timings = make([]Timing, len(r.Timings))
+		copy(c.Timings, r.Timings)
+	}
+
+	if r.Links != nil {
+		c.Links = make([]Link, len(r.Links))
+		copy(c.Links, r.Links)
+	}
+
+	if r.Meta != nil {
+		c.Meta = maps.Clone(r.Meta)
+	}
+
+	if r.timers != nil {
+		c.timers = make(map[string]time.Time, len(r.timers))
+		for name, start := range r.timers {
+			c.timers[name] = start
+		}
+	}
+
+	if r.Deprecation != nil {
+		info := *r.Deprecation
+		c.Deprecation = &info
+	}
+
+	if r.PaginationData != nil {
+		meta := *r.PaginationData
+		if meta.NextPage != nil {
+			next := *meta.NextPage
+			meta.NextPage = &next
+		}
+		if meta.PrevPage != nil {
+			prev := *meta.PrevPage
+			meta.PrevPage = &prev
+		}
+		if meta.Links != nil {
+			links := *meta.Links
+			meta.Links = &links
+		}
+		c.PaginationData = &meta
+	}
+
+	if r.QueryData != nil {
+		query := QueryMeta{
+			Sort:    make([]SortField, len(r.QueryData.Sort)),
+			Filters: make([]Filter, len(r.QueryData.Filters)),
+		}
+		copy(query.Sort, r.QueryData.Sort)
+		copy(query.Filters, r.QueryData.Filters)
+		c.QueryData = &query
+	}
+
+	return &c
+}
+
+// Template registers a canned response under name on this Responder
+// A copy is stored so later changes to r don't affect the template
+func (rs *Responder) Template(name string, r *Response) {
+	rs.templatesMu.Lock()
+	defer rs.templatesMu.Unlock()
+
+	if rs.templates == nil {
+		rs.templates = make(map[string]*Response)
+	}
+	rs.templates[name] = r.Clone()
+}
+
+// FromTemplate returns a fresh copy of the named template with a new timestamp
+func (rs *Responder) FromTemplate(name string) *Response {
+	rs.templatesMu.RLock()
+	tmpl, ok := rs.templates[name]
+	rs.templatesMu.RUnlock()
+
+	if !ok {
+		return rs.InternalServerError("Unknown response template").
+			appendTraceInternal("error", fmt.Sprintf("template %q is not registered", name))
+	}
+
+	r := tmpl.Clone()
+	r.Timestamp = r.getResponseConfig().now()
+	return r
+}
+
+// Template registers a canned response on the default Responder
+func Template(name string, r *Response) {
+	defaultResponder.Template(name, r)
+}
+
+// FromTemplate stamps a copy of a template registered on the default Responder
+func FromTemplate(name string) *Response {
+	return defaultResponder.FromTemplate(name)
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"strconv"
+	"time"
+)
+
+// Timing is a named duration measured while building the response
+type Timing struct {
+	Name     string        `json:"name" xml:"name"`
+	Duration time.Duration `json:"-" xml:"-"`
+	Millis   float64       `json:"duration_ms" xml:"duration_ms"`
+}
+
+// StartTimer starts measuring a named step, restarting it if already running
+func (r *Response) StartTimer(name string) *Response {
+	if r.timers == nil {
+		r.timers = make(map[string]time.Time)
+	}
+	r.timers[name] = r.getResponseConfig().now()
+	return r
+}
+
+// EndTimer stops a timer started with StartTimer, recording its duration in
+// Timings and as a debug trace entry. Unknown timers are ignored
+func (r *Response) EndTimer(name string) *Response {
+	start, ok := r.timers[name]
+	if !ok {
+		return r
+	}
+	delete(r.timers, name)
+
+	elapsed := r.getResponseConfig().now().Sub(start)
+	millis := float64(elapsed.Microseconds()) / 1000
+	r.Timings = append(r.Timings, Timing{Name: name, Duration: elapsed, Millis: millis})
+	return r.appendTrace(TraceLevelDebug, "timer", false,
+		name+" took "+strconv.FormatFloat(millis, 'f', -1, 64)+"ms")
+}
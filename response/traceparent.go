@@ -0,0 +1,104 @@
+package response
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// TraceParent is a parsed W3C Trace Context traceparent header
+type TraceParent struct {
+	TraceID  string // 32 lowercase hex characters
+	ParentID string // 16 lowercase hex characters
+	Flags    string // 2 lowercase hex characters, "01" when sampled
+}
+
+// String formats the traceparent header value
+func (tp TraceParent) String() string {
+	return "00-" + tp.TraceID + "-" + tp.ParentID + "-" + tp.Flags
+}
+
+// ParseTraceParent parses a version 00 traceparent header value
+func ParseTraceParent(value string) (TraceParent, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return TraceParent{}, false
+	}
+
+	tp := TraceParent{TraceID: parts[1], ParentID: parts[2], Flags: parts[3]}
+	if !isHexID(tp.TraceID, 32) || !isHexID(tp.ParentID, 16) || !isHexID(tp.Flags, 2) {
+		return TraceParent{}, false
+	}
+	return tp, true
+}
+
+// NewTraceParent starts a new sampled trace with random identifiers
+func NewTraceParent() TraceParent {
+	return TraceParent{TraceID: randomHex(16), ParentID: randomHex(8), Flags: "01"}
+}
+
+// isHexID reports whether s is n lowercase hex characters and not all zeros
+func isHexID(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	zero := true
+	for _, c := range s {
+		switch {
+		case c == '0':
+		case c >= '1' && c <= '9', c >= 'a' && c <= 'f':
+			zero = false
+		default:
+			return false
+		}
+	}
+	return !zero || n == 2
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+type traceParentKey struct{}
+
+// ContextWithTraceParent stores tp in the context
+func ContextWithTraceParent(ctx context.Context, tp TraceParent) context.Context {
+	return context.WithValue(ctx, traceParentKey{}, tp)
+}
+
+// TraceParentFromContext returns the traceparent stored in the context
+func TraceParentFromContext(ctx context.Context) (TraceParent, bool) {
+	tp, ok := ctx.Value(traceParentKey{}).(TraceParent)
+	return tp, ok
+}
+
+// TraceParentMiddleware reads the incoming traceparent header, starting a new
+// trace when it is missing or invalid, stores it in the request context and
+// echoes it on the response. Responses sent with SendWithRequest or
+// SendWithContext then carry its trace_id in the envelope
+func TraceParentMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		tp, ok := ParseTraceParent(req.Header.Get("traceparent"))
+		if !ok {
+			tp = NewTraceParent()
+		}
+
+		w.Header().Set("traceparent", tp.String())
+		next.ServeHTTP(w, req.WithContext(ContextWithTraceParent(req.Context(), tp)))
+	})
+}
+
+// applyTraceParent fills TraceID from the traceparent in the context unless
+// it was already set
+func (r *Response) applyTraceParent(ctx context.Context) {
+	if r.TraceID != "" || ctx == nil {
+		return
+	}
+	if tp, ok := TraceParentFromContext(ctx); ok {
+		r.TraceID = tp.TraceID
+	}
+}
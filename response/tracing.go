@@ -1,33 +1,174 @@
 package response
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// TraceLevel ranks trace entries so verbose ones can be filtered out with
+// Config.MinTraceLevel
+type TraceLevel int
+
+const (
+	TraceLevelDebug TraceLevel = iota + 1
+	TraceLevelInfo
+	TraceLevelWarn
+	TraceLevelError
+)
+
+func (l TraceLevel) String() string {
+	switch l {
+	case TraceLevelDebug:
+		return "debug"
+	case TraceLevelInfo:
+		return "info"
+	case TraceLevelWarn:
+		return "warn"
+	case TraceLevelError:
+		return "error"
+	}
+	return ""
+}
+
+func (l TraceLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l *TraceLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "debug":
+		*l = TraceLevelDebug
+	case "info":
+		*l = TraceLevelInfo
+	case "warn":
+		*l = TraceLevelWarn
+	case "error":
+		*l = TraceLevelError
+	case "":
+		*l = 0
+	default:
+		return fmt.Errorf("unknown trace level %q", text)
+	}
+	return nil
+}
+
+// TraceEntry is the structured form of a trace line, serialized in place of
+// the plain strings when Config.StructuredTrace is set
+type TraceEntry struct {
+	Time    time.Time  `json:"ts,omitzero" xml:"ts"`
+	Level   TraceLevel `json:"level,omitempty" xml:"level,omitempty"`
+	Prefix  string     `json:"prefix,omitempty" xml:"prefix,omitempty"`
+	Message string     `json:"message" xml:"message"`
+	Caller  string     `json:"caller,omitempty" xml:"caller,omitempty"` // file:line, with Config.TraceCallers
+}
+
+// WithTraceIDs embeds distributed tracing identifiers in the envelope so
+// clients can correlate a response with the server side trace
+func (r *Response) WithTraceIDs(traceID, spanID string) *Response {
+	r.TraceID = traceID
+	r.SpanID = spanID
+	return r
+}
 
 // Takes in strings, errors and Stringers
 func (r *Response) AddTrace(trace ...any) *Response {
-	if r.TracePrefix == "" {
-		return r.appendTrace("trace", false, trace...)
-	}
-	return r.appendTrace(r.TracePrefix, false, trace...)
+	return r.appendTrace(TraceLevelInfo, r.tracePrefix(), false, trace...)
 }
 
 // Takes in strings, errors and Stringers
 func (r *Response) AddPrefixedTrace(prefix string, trace ...any) *Response {
 	if prefix == "" {
-		return r.appendTrace("trace", false, trace...)
+		prefix = "trace"
+	}
+	return r.appendTrace(TraceLevelInfo, prefix, false, trace...)
+}
+
+// AddDebugTrace adds entries at debug level
+func (r *Response) AddDebugTrace(trace ...any) *Response {
+	return r.appendTrace(TraceLevelDebug, r.tracePrefix(), false, trace...)
+}
+
+// AddInfoTrace adds entries at info level, like AddTrace
+func (r *Response) AddInfoTrace(trace ...any) *Response {
+	return r.appendTrace(TraceLevelInfo, r.tracePrefix(), false, trace...)
+}
+
+// AddWarnTrace adds entries at warn level
+func (r *Response) AddWarnTrace(trace ...any) *Response {
+	return r.appendTrace(TraceLevelWarn, r.tracePrefix(), false, trace...)
+}
+
+// AddErrorTrace adds entries at error level
+func (r *Response) AddErrorTrace(trace ...any) *Response {
+	return r.appendTrace(TraceLevelError, r.tracePrefix(), false, trace...)
+}
+
+// AddTracef adds a formatted entry. Formatting is skipped when the entry is
+// filtered out by level or the trace is full
+func (r *Response) AddTracef(format string, args ...any) *Response {
+	return r.appendTrace(TraceLevelInfo, r.tracePrefix(), false, tracef{format, args})
+}
+
+// AddPrefixedTracef adds a formatted entry under prefix
+func (r *Response) AddPrefixedTracef(prefix, format string, args ...any) *Response {
+	if prefix == "" {
+		prefix = "trace"
+	}
+	return r.appendTrace(TraceLevelInfo, prefix, false, tracef{format, args})
+}
+
+// tracef formats its arguments only when the trace asks for the string
+type tracef struct {
+	format string
+	args   []any
+}
+
+func (t tracef) String() string {
+	return fmt.Sprintf(t.format, t.args...)
+}
+
+func (r *Response) tracePrefix() string {
+	if r.TracePrefix == "" {
+		return "trace"
 	}
-	return r.appendTrace(prefix, false, trace...)
+	return r.TracePrefix
 }
 
 // AppendTraceInternal is for internal use and can override the last trace entry when full
+// Internal entries explain a failure so they are recorded at error level
 func (r *Response) appendTraceInternal(prefix string, trace ...any) *Response {
-	return r.appendTrace(prefix, true, trace...)
+	return r.appendTrace(TraceLevelError, prefix, true, trace...)
 }
 
 // Internal trace appending logic
-func (r *Response) appendTrace(prefix string, force bool, trace ...any) *Response {
+// Entries below Config.MinTraceLevel are dropped before being formatted
+func (r *Response) appendTrace(level TraceLevel, prefix string, force bool, trace ...any) *Response {
 	config := r.getResponseConfig()
+	if level < config.MinTraceLevel {
+		return r
+	}
+
+	var caller string
+	if config.TraceCallers {
+		caller = callerLocation()
+	}
 
 	for _, t := range trace {
+		full := len(r.Trace) >= config.MaxTraceSize
+		if full && (!force || config.MaxTraceSize <= 0) {
+			if config.MaxTraceSize > 0 {
+				truncMsg := fmt.Sprintf("Error: (trace truncated, max size: %d)", config.MaxTraceSize)
+				if r.Trace[config.MaxTraceSize-1] != truncMsg {
+					r.setTrace(config.MaxTraceSize-1, truncMsg, newTraceEntry(config.now(), TraceLevelWarn, "trace",
+						fmt.Sprintf("trace truncated, max size: %d", config.MaxTraceSize)))
+				}
+			}
+			break
+		}
+
+		// Converted only once the entry is known to be kept, so lazy
+		// Stringers such as AddTracef arguments are never formatted for nothing
 		var traceStr string
 		switch v := t.(type) {
 		case string:
@@ -40,21 +181,84 @@ func (r *Response) appendTrace(prefix string, force bool, trace ...any) *Respons
 			continue
 		}
 
-		traceStrFull := prefix + ": " + traceStr
-
-		if len(r.Trace) < config.MaxTraceSize {
-			r.Trace = append(r.Trace, traceStrFull)
-		} else {
-			if force && config.MaxTraceSize > 0 {
-				r.Trace[config.MaxTraceSize-1] = traceStr
-			} else if !force && config.MaxTraceSize > 0 {
-				truncMsg := fmt.Sprintf("Error: (trace truncated, max size: %d)", config.MaxTraceSize)
-				if r.Trace[config.MaxTraceSize-1] != truncMsg {
-					r.Trace[config.MaxTraceSize-1] = truncMsg
-				}
+		if config.MaxTraceBytes > 0 {
+			used := r.traceBytes()
+			if full {
+				used -= len(r.Trace[config.MaxTraceSize-1])
+			}
+			var ok bool
+			if traceStr, ok = fitTrace(prefix, traceStr, config.MaxTraceBytes-used); !ok {
 				break
 			}
 		}
+
+		entry := newTraceEntry(config.now(), level, prefix, traceStr)
+		entry.Caller = caller
+
+		if full {
+			r.setTrace(config.MaxTraceSize-1, traceStr, entry)
+			continue
+		}
+		r.alignTraceEntries()
+		r.Trace = append(r.Trace, prefix+": "+traceStr)
+		r.TraceEntries = append(r.TraceEntries, entry)
 	}
 	return r
 }
+
+// alignTraceEntries pads TraceEntries with empty entries for the lines
+// appended to Trace directly, so entry i always describes line i
+func (r *Response) alignTraceEntries() {
+	if len(r.TraceEntries) > len(r.Trace) {
+		r.TraceEntries = r.TraceEntries[:len(r.Trace)]
+	}
+	for len(r.TraceEntries) < len(r.Trace) {
+		r.TraceEntries = append(r.TraceEntries, TraceEntry{})
+	}
+}
+
+const traceTruncatedSuffix = "... (truncated)"
+
+// traceBytes is the size of the trace lines
+func (r *Response) traceBytes() int {
+	n := 0
+	for _, line := range r.Trace {
+		n += len(line)
+	}
+	return n
+}
+
+// fitTrace shortens message so the "prefix: message" line fits in remaining
+// bytes, reporting false when not even a truncated line fits
+func fitTrace(prefix, message string, remaining int) (string, bool) {
+	overhead := len(prefix) + len(": ")
+	if overhead+len(message) <= remaining {
+		return message, true
+	}
+
+	room := remaining - overhead - len(traceTruncatedSuffix)
+	if room <= 0 {
+		return "", false
+	}
+	// Cut on a rune boundary
+	for room > 0 && !utf8.RuneStart(message[room]) {
+		room--
+	}
+	return message[:room] + traceTruncatedSuffix, true
+}
+
+func newTraceEntry(now time.Time, level TraceLevel, prefix, message string) TraceEntry {
+	return TraceEntry{
+		Time:    now,
+		Level:   level,
+		Prefix:  prefix,
+		Message: message,
+	}
+}
+
+// setTrace overwrites trace line i in both forms
+func (r *Response) setTrace(i int, line string, entry TraceEntry) {
+	r.alignTraceEntries()
+	r.Trace[i] = line
+	r.TraceEntries[i] = entry
+}
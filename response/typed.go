@@ -0,0 +1,34 @@
+package response
+
+import "net/http"
+
+// Typed is a Response whose Data is statically typed as T
+// All Response methods remain available through the embedded Response
+type Typed[T any] struct {
+	*Response
+}
+
+// WithDataT attaches strongly typed data to a response
+func WithDataT[T any](r *Response, data T) *Typed[T] {
+	r.Data = data
+	return &Typed[T]{Response: r}
+}
+
+// WithData replaces the data keeping its static type
+func (t *Typed[T]) WithData(data T) *Typed[T] {
+	t.Response.Data = data
+	return t
+}
+
+// Value returns the typed data, or the zero value of T if none is set
+func (t *Typed[T]) Value() T {
+	v, _ := t.Response.Data.(T)
+	return v
+}
+
+// ExtractDataT is the typed counterpart of ExtractData, decoding Data into a T
+func ExtractDataT[T any](httpResp *http.Response) (*Response, T, error) {
+	var data T
+	r, err := ExtractData(httpResp, &data)
+	return r, data, err
+}
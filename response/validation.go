@@ -8,6 +8,9 @@ type ValidationTrace struct {
 	Value   any    `json:"value,omitempty"`
 }
 
+// AddValidationErrors builds a Bad Request with one structured error per
+// violation, for clients and for formats that report field violations on their
+// own, and a matching validation trace carrying the offending value
 func AddValidationErrors(errs ...ValidationTrace) *Response {
 	if len(errs) == 0 {
 		return BadRequest("Validation failed")
@@ -23,6 +26,7 @@ func AddValidationErrors(errs ...ValidationTrace) *Response {
 			traceMsg = fmt.Sprintf("(%s) %s", err.Field, err.Message)
 		}
 		r.appendTraceInternal("validation", traceMsg)
+		r.Errors = append(r.Errors, ErrorDetail{Field: err.Field, Message: err.Message})
 	}
 
 	return r
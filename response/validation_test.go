@@ -0,0 +1,46 @@
+package response
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddValidationErrors(t *testing.T) {
+	errs := []ValidationTrace{
+		{Field: "email", Message: "must be a valid address", Value: "not-an-email"},
+		{Field: "items[0].sku", Message: "is required"},
+	}
+
+	tests := []struct {
+		name   string
+		format func(*Response) *Response
+		want   []string
+	}{
+		{"envelope", func(r *Response) *Response { return r }, []string{`"field":"email"`, `"field":"items[0].sku"`}},
+		{"google", (*Response).AsGoogleError, []string{`"fieldViolations"`, `"field":"email"`, `"description":"is required"`}},
+		{"graphql", (*Response).AsGraphQLErrors, []string{`"path":["email"]`, `"message":"items[0].sku is required"`}},
+		{"odata", func(r *Response) *Response { return r.AsOData("") }, []string{`"target":"email"`, `"target":"items[0].sku"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := AddValidationErrors(errs...)
+			if len(r.Errors) != len(errs) || len(r.Trace) != len(errs) {
+				t.Fatalf("got %d errors and %d traces, want %d of each", len(r.Errors), len(r.Trace), len(errs))
+			}
+
+			body, err := tt.format(r).RenderString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body lacks %s: %s", want, body)
+				}
+			}
+			if n := strings.Count(body, "is required"); n > 2 {
+				t.Errorf("violation reported %d times: %s", n, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/MintzyG/FastUtilitiesNet/response/yamlenc
+
+go 1.25.3
+
+replace github.com/MintzyG/FastUtilitiesNet => ../..
+
+require (
+	github.com/MintzyG/FastUtilitiesNet v0.0.0-00010101000000-000000000000
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,52 @@
+// Package yamlenc provides a YAML encoder for response envelopes.
+package yamlenc
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"gopkg.in/yaml.v3"
+)
+
+// MediaType is the media type the encoder is registered under
+const MediaType = "application/yaml"
+
+// Encoder renders the envelope as YAML
+// The envelope is converted through JSON first so Data types only need json tags
+var Encoder = response.EncoderFunc(func(w io.Writer, r *response.Response) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+})
+
+// Register adds the YAML encoder to the given Responder
+func Register(rs ...*response.Responder) {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+	target.RegisterEncoder(MediaType, Encoder)
+}
+
+// resetStyle drops the JSON flow and quoting styles so the output is block YAML
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
@@ -0,0 +1,39 @@
+package yamlenc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+)
+
+func TestEncoder(t *testing.T) {
+	rs := response.NewResponder(response.Config{})
+	Register(rs)
+
+	tests := []struct {
+		name string
+		resp *response.Response
+		want []string
+	}{
+		{"message", rs.Created("order created"), []string{"message: order created\n", "code: 201\n"}},
+		{"nested data", rs.OK().WithData(map[string]any{"items": []string{"a", "b"}}), []string{"data:\n  items:\n    - a\n    - b\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := tt.resp.WithContentType(MediaType).RenderString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body lacks %q:\n%s", want, body)
+				}
+			}
+			if strings.ContainsAny(body, "{}") {
+				t.Errorf("body uses flow style:\n%s", body)
+			}
+		})
+	}
+}
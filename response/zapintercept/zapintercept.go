@@ -0,0 +1,138 @@
+// Package zapintercept logs responses with go.uber.org/zap.
+package zapintercept
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Interceptor logs every response it sees as one structured entry
+type Interceptor struct {
+	logger     *zap.Logger
+	message    string
+	level      func(statusCode int) zapcore.Level
+	traceFrom  int
+	includeLen bool
+}
+
+// Option configures an Interceptor
+type Option func(*Interceptor)
+
+// WithLevel sets how status codes map to log levels. By default 5xx log at
+// error, 4xx at warn and everything else at info
+func WithLevel(level func(statusCode int) zapcore.Level) Option {
+	return func(i *Interceptor) {
+		i.level = level
+	}
+}
+
+// WithTraceFrom logs the trace of responses with a status code of at least
+// minStatus, 400 by default. Use a value above 599 to never log traces
+func WithTraceFrom(minStatus int) Option {
+	return func(i *Interceptor) {
+		i.traceFrom = minStatus
+	}
+}
+
+// WithSize adds the size of the encoded envelope. It costs an extra encoding
+// of every response
+func WithSize() Option {
+	return func(i *Interceptor) {
+		i.includeLen = true
+	}
+}
+
+// WithMessage sets the log message, "response" by default
+func WithMessage(msg string) Option {
+	return func(i *Interceptor) {
+		i.message = msg
+	}
+}
+
+// DefaultLevel maps 5xx to error, 4xx to warn and the rest to info
+func DefaultLevel(statusCode int) zapcore.Level {
+	switch {
+	case statusCode >= 500:
+		return zapcore.ErrorLevel
+	case statusCode >= 400:
+		return zapcore.WarnLevel
+	}
+	return zapcore.InfoLevel
+}
+
+// New creates an interceptor logging to logger, zap.L() if nil
+func New(logger *zap.Logger, opts ...Option) *Interceptor {
+	if logger == nil {
+		logger = zap.L()
+	}
+	i := &Interceptor{
+		logger:    logger,
+		message:   "response",
+		level:     DefaultLevel,
+		traceFrom: 400,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+func (i *Interceptor) Intercept(_ context.Context, resp *response.Response, statusCode int) {
+	i.log(nil, resp, statusCode)
+}
+
+func (i *Interceptor) InterceptSimple(resp *response.Response, statusCode int) {
+	i.log(nil, resp, statusCode)
+}
+
+// InterceptRequest adds the request method and path to the entry
+func (i *Interceptor) InterceptRequest(req *http.Request, resp *response.Response, statusCode int) {
+	i.log(req, resp, statusCode)
+}
+
+func (i *Interceptor) log(req *http.Request, resp *response.Response, statusCode int) {
+	ce := i.logger.Check(i.level(statusCode), i.message)
+	if ce == nil {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Int("code", statusCode),
+		zap.String("module", resp.Module),
+	}
+	if resp.Message != "" {
+		fields = append(fields, zap.String("message", resp.Message))
+	}
+	if resp.ErrorCode != "" {
+		fields = append(fields, zap.String("error_code", resp.ErrorCode))
+	}
+	if req != nil {
+		fields = append(fields, zap.String("method", req.Method), zap.String("path", req.URL.Path))
+	}
+	if resp.TraceID != "" {
+		fields = append(fields, zap.String("trace_id", resp.TraceID))
+	}
+	if i.includeLen {
+		if size, ok := resp.GetResponseStats()["size_bytes"].(int); ok {
+			fields = append(fields, zap.Int("size", size))
+		}
+	}
+	if statusCode >= i.traceFrom && len(resp.Trace) > 0 {
+		fields = append(fields, zap.Strings("trace", resp.Trace))
+	}
+
+	ce.Write(fields...)
+}
+
+// Register adds a logging interceptor to the given Responder
+func Register(logger *zap.Logger, opts []Option, rs ...*response.Responder) error {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+	return target.AddNamedInterceptor("zap", New(logger, opts...))
+}
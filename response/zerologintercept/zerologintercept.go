@@ -0,0 +1,154 @@
+// Package zerologintercept logs responses with github.com/rs/zerolog.
+package zerologintercept
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MintzyG/FastUtilitiesNet/response"
+	"github.com/rs/zerolog"
+)
+
+// Interceptor logs every response it sees as one structured event
+type Interceptor struct {
+	logger     zerolog.Logger
+	message    string
+	levels     [6]zerolog.Level
+	traceFrom  int
+	entries    bool
+	includeLen bool
+}
+
+// Option configures an Interceptor
+type Option func(*Interceptor)
+
+// WithClassLevel sets the level used for a status class, 1 for 1xx through 5
+// for 5xx. By default 5xx log at error, 4xx at warn and everything else at info
+func WithClassLevel(class int, level zerolog.Level) Option {
+	return func(i *Interceptor) {
+		if class >= 1 && class <= 5 {
+			i.levels[class] = level
+		}
+	}
+}
+
+// WithTraceFrom logs the trace of responses with a status code of at least
+// minStatus, 400 by default. Use a value above 599 to never log traces
+func WithTraceFrom(minStatus int) Option {
+	return func(i *Interceptor) {
+		i.traceFrom = minStatus
+	}
+}
+
+// WithTraceEntries logs structured trace entries, with their level, prefix
+// and caller, instead of the flat trace strings
+func WithTraceEntries() Option {
+	return func(i *Interceptor) {
+		i.entries = true
+	}
+}
+
+// WithSize adds the size of the encoded envelope. It costs an extra encoding
+// of every response
+func WithSize() Option {
+	return func(i *Interceptor) {
+		i.includeLen = true
+	}
+}
+
+// WithMessage sets the log message, "response" by default
+func WithMessage(msg string) Option {
+	return func(i *Interceptor) {
+		i.message = msg
+	}
+}
+
+// New creates an interceptor logging to logger
+func New(logger zerolog.Logger, opts ...Option) *Interceptor {
+	i := &Interceptor{
+		logger:    logger,
+		message:   "response",
+		traceFrom: 400,
+		levels: [6]zerolog.Level{
+			zerolog.InfoLevel,
+			zerolog.InfoLevel,
+			zerolog.InfoLevel,
+			zerolog.InfoLevel,
+			zerolog.WarnLevel,
+			zerolog.ErrorLevel,
+		},
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+func (i *Interceptor) Intercept(ctx context.Context, resp *response.Response, statusCode int) {
+	i.log(ctx, nil, resp, statusCode)
+}
+
+func (i *Interceptor) InterceptSimple(resp *response.Response, statusCode int) {
+	i.log(context.Background(), nil, resp, statusCode)
+}
+
+// InterceptRequest adds the request method and path to the event
+func (i *Interceptor) InterceptRequest(req *http.Request, resp *response.Response, statusCode int) {
+	i.log(req.Context(), req, resp, statusCode)
+}
+
+func (i *Interceptor) level(statusCode int) zerolog.Level {
+	class := statusCode / 100
+	if class < 1 || class > 5 {
+		return zerolog.InfoLevel
+	}
+	return i.levels[class]
+}
+
+func (i *Interceptor) log(ctx context.Context, req *http.Request, resp *response.Response, statusCode int) {
+	e := i.logger.WithLevel(i.level(statusCode))
+	if e == nil {
+		return
+	}
+
+	e = e.Ctx(ctx).
+		Int("code", statusCode).
+		Str("module", resp.Module)
+	if resp.Message != "" {
+		// "message" is zerolog's own message field
+		e = e.Str("response_message", resp.Message)
+	}
+	if resp.ErrorCode != "" {
+		e = e.Str("error_code", resp.ErrorCode)
+	}
+	if req != nil {
+		e = e.Str("method", req.Method).Str("path", req.URL.Path)
+	}
+	if resp.TraceID != "" {
+		e = e.Str("trace_id", resp.TraceID)
+	}
+	if i.includeLen {
+		if size, ok := resp.GetResponseStats()["size_bytes"].(int); ok {
+			e = e.Int("size", size)
+		}
+	}
+	if statusCode >= i.traceFrom {
+		switch {
+		case i.entries && len(resp.TraceEntries) > 0:
+			e = e.Interface("trace", resp.TraceEntries)
+		case len(resp.Trace) > 0:
+			e = e.Strs("trace", resp.Trace)
+		}
+	}
+
+	e.Msg(i.message)
+}
+
+// Register adds a logging interceptor to the given Responder
+func Register(logger zerolog.Logger, opts []Option, rs ...*response.Responder) error {
+	target := response.Default()
+	if len(rs) > 0 && rs[0] != nil {
+		target = rs[0]
+	}
+	return target.AddNamedInterceptor("zerolog", New(logger, opts...))
+}